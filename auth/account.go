@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/xuperchain/crypto/client/service/xchain"
 )
 
@@ -39,6 +41,16 @@ type Account struct {
 	Mnemonic string `json:"mnemonic,omitempy"`
 }
 
+// String 隐藏私钥和助记词，避免打印到日志
+func (t Account) String() string {
+	return fmt.Sprintf("{Address:%s PublicKey:%s PrivateKey:%s Mnemonic:%s}",
+		t.Address, t.PublicKey, MaskSecret(t.PrivateKey), MaskSecret(t.Mnemonic))
+}
+
+func (t Account) GoString() string {
+	return t.String()
+}
+
 // 新创建xuperchain ecdsa账户
 func NewXchainEcdsaAccount(strg MnemStrgth, lang MnemLang) (*Account, error) {
 	cryptoCli := &xchain.XchainCryptoClient{}
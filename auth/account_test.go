@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -38,3 +39,19 @@ func TestRetrieveAccountByMnemonic(t *testing.T) {
 		t.Errorf("retrieve account. before:%v, after:%v", acc.PrivateKey, rc.PrivateKey)
 	}
 }
+
+func TestSecretString(t *testing.T) {
+	acc := &Account{Address: "addr", PrivateKey: "private-key-secret", PublicKey: "pub", Mnemonic: "mnemonic secret"}
+	cred := &Credentials{AppId: 1, AccessKeyId: "ak", SecretAccessKey: "sk-secret"}
+	for _, msg := range []string{
+		fmt.Sprintf("%v %+v %#v %s", acc, acc, acc, *acc),
+		fmt.Sprintf("%v %+v %#v %s", cred, cred, cred, *cred),
+		fmt.Sprintf("%+v", struct{ Account *Account }{acc}),
+	} {
+		for _, secret := range []string{"private-key-secret", "mnemonic secret", "sk-secret"} {
+			if strings.Contains(msg, secret) {
+				t.Errorf("secret leaked.[secret:%s] [msg:%s]", secret, msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+// Signer 资产操作签名者，接口参数中的账户均使用Signer，
+// 私钥可以保存在KMS、HSM等密钥服务中，不需要加载到SDK进程内。实现需要并发安全
+type Signer interface {
+	// GetAddress 区块链地址
+	GetAddress() string
+	// GetPublicKey json格式公钥
+	GetPublicKey() string
+	// Sign 对原始消息签名，返回hex编码的签名，算法与XassetSignECDSA一致
+	// ctx为接口调用的ctx，访问远程密钥服务的实现需要在ctx取消或超时时返回
+	Sign(ctx context.Context, msg []byte) (string, error)
+}
+
+var _ Signer = (*Account)(nil)
+
+// GetAddress Account作为进程内Signer使用，私钥为内存中的json字符串
+func (t *Account) GetAddress() string {
+	return t.Address
+}
+
+func (t *Account) GetPublicKey() string {
+	return t.PublicKey
+}
+
+// Sign 进程内签名，不使用ctx
+func (t *Account) Sign(_ context.Context, msg []byte) (string, error) {
+	return XassetSignECDSA(t.PrivateKey, msg)
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// 预签名地址中认证字符串的参数名
+	PresignAuthParam = "authorization"
+	// 预签名地址的最大有效期，与CheckSign允许的最大有效期一致
+	PresignMaxExpireSeconds = 3600
+)
+
+// PresignUrl 生成GET请求的预签名地址，认证字符串放在authorization参数中，只签名host，
+// 持有地址的一方在有效期内不需要AK/SK即可访问。opt.HeadersToSign不生效，opt为nil时使用默认有效期
+// 地址中已有的authorization参数会被替换
+func PresignUrl(rawUrl string, cred *Credentials, opt *SignOptions) (string, error) {
+	if cred == nil {
+		return "", fmt.Errorf("param error")
+	}
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("presign url invalid.url:%s", rawUrl)
+	}
+
+	signOpt := &SignOptions{
+		HeadersToSign: map[string]struct{}{"host": {}},
+		ExpireSeconds: DEFAULT_EXPIRE_SECONDS,
+	}
+	if opt != nil {
+		signOpt.Timestamp = opt.Timestamp
+		if opt.ExpireSeconds > 0 {
+			signOpt.ExpireSeconds = opt.ExpireSeconds
+		}
+	}
+	if signOpt.ExpireSeconds > PresignMaxExpireSeconds {
+		return "", fmt.Errorf("presign expire seconds must not exceed %d", PresignMaxExpireSeconds)
+	}
+
+	u.RawQuery = removeQueryParam(u.RawQuery, PresignAuthParam)
+	u.Fragment = ""
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    u,
+		Host:   u.Host,
+		Header: http.Header{},
+	}
+	author, err := Sign(req, cred, signOpt)
+	if err != nil {
+		return "", err
+	}
+
+	if u.RawQuery != "" {
+		u.RawQuery += "&"
+	}
+	u.RawQuery += PresignAuthParam + "=" + url.QueryEscape(author)
+	return u.String(), nil
+}
+
+// removeQueryParam 删除rawQuery中名为key的参数（不区分大小写），保留其他参数的原始编码
+func removeQueryParam(rawQuery, key string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	kept := make([]string, 0)
+	for _, query := range strings.Split(rawQuery, "&") {
+		k := query
+		if idx := strings.Index(query, "="); idx >= 0 {
+			k = query[:idx]
+		}
+		if query == "" || strings.EqualFold(queryUnescape(k), key) {
+			continue
+		}
+		kept = append(kept, query)
+	}
+	return strings.Join(kept, "&")
+}
+
+// getAuthorization 读取请求的认证字符串，header中没有时读取预签名地址中的authorization参数
+func getAuthorization(req *http.Request) (string, bool) {
+	if author := req.Header.Get("Authorization"); author != "" {
+		return author, false
+	}
+	for _, query := range strings.Split(req.URL.RawQuery, "&") {
+		idx := strings.Index(query, "=")
+		if idx < 0 || !strings.EqualFold(queryUnescape(query[:idx]), PresignAuthParam) {
+			continue
+		}
+		if author := queryUnescape(query[idx+1:]); author != "" {
+			return author, true
+		}
+	}
+	return "", false
+}
+
+// isPresignMethod 预签名地址只用于只读请求
+func isPresignMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
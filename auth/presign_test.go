@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPresignUrl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := CheckSign(r, verifyCred); err != nil {
+			http.Error(w, err.Error(), 401)
+			return
+		}
+		w.Write([]byte(r.URL.Query().Get("asset_id")))
+	}))
+	defer srv.Close()
+
+	signed, err := PresignUrl(srv.URL+"/xasset/horae/v1/query?asset_id=1&token=a%3Db==&authorization=old",
+		verifyCred, &SignOptions{ExpireSeconds: 600})
+	if err != nil {
+		t.Fatalf("presign url failed.err:%v", err)
+	}
+	u, _ := url.Parse(signed)
+	if strings.Contains(signed, "old") || !strings.Contains(u.Query().Get(PresignAuthParam), "/600/host/") {
+		t.Fatalf("presigned url not match.url:%s", signed)
+	}
+
+	// 有效期内可以重复访问
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(signed)
+		if err != nil || resp.StatusCode != 200 {
+			t.Fatalf("get presigned url failed.err:%v resp:%+v", err, resp)
+		}
+		resp.Body.Close()
+	}
+	resp, _ := http.Get(strings.Replace(signed, "asset_id=1", "asset_id=2", 1))
+	if resp.StatusCode != 401 {
+		t.Errorf("tampered presigned url should be rejected.code:%d", resp.StatusCode)
+	}
+	resp.Body.Close()
+	resp, _ = http.Post(signed, "application/x-www-form-urlencoded", nil)
+	if resp.StatusCode != 401 {
+		t.Errorf("presigned url should only allow GET.code:%d", resp.StatusCode)
+	}
+	resp.Body.Close()
+
+	if _, err := PresignUrl(srv.URL, verifyCred, &SignOptions{ExpireSeconds: PresignMaxExpireSeconds + 1}); err == nil {
+		t.Errorf("expire over max should be rejected")
+	}
+	if _, err := PresignUrl("/xasset/horae/v1/query", verifyCred, nil); err == nil {
+		t.Errorf("url without host should be rejected")
+	}
+}
+
+func TestVerifierPresignedUrl(t *testing.T) {
+	signed, _ := PresignUrl("http://xasset.test/xasset/horae/v1/query?asset_id=1", verifyCred, nil)
+
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	if _, err := v.Verify(httptest.NewRequest("GET", signed, nil)); !errors.Is(err, ErrSignFormat) {
+		t.Errorf("presigned url should be disabled by default.err:%v", err)
+	}
+
+	cfg := NewVerifierConfig()
+	cfg.AllowPresignedUrl = true
+	v = NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+	for i := 0; i < 2; i++ {
+		if cred, err := v.Verify(httptest.NewRequest("GET", signed, nil)); err != nil || cred.AppId != 1 {
+			t.Fatalf("verify presigned url failed.err:%v", err)
+		}
+	}
+	if _, err := v.Verify(httptest.NewRequest("POST", signed, nil)); !errors.Is(err, ErrSignFormat) {
+		t.Errorf("presigned url should only allow GET.err:%v", err)
+	}
+	tampered := strings.Replace(signed, "asset_id=1", "asset_id=2", 1)
+	if _, err := v.Verify(httptest.NewRequest("GET", tampered, nil)); !errors.Is(err, ErrSignMismatch) {
+		t.Errorf("tampered presigned url should be rejected.err:%v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 环境变量凭证，与配置加载使用的环境变量名一致
+const (
+	EnvAppId           = "XASSET_APP_ID"
+	EnvAccessKeyId     = "XASSET_ACCESS_KEY_ID"
+	EnvSecretAccessKey = "XASSET_SECRET_ACCESS_KEY"
+)
+
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// CredentialsProvider 准入凭证来源，客户端每次请求都会调用Retrieve获取凭证，
+// 实现需要并发安全，并自行缓存避免每次请求都访问外部存储
+type CredentialsProvider interface {
+	Retrieve() (*Credentials, error)
+}
+
+func validCredentials(cred *Credentials, from string) (*Credentials, error) {
+	if cred == nil || cred.AccessKeyId == "" || cred.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w: access key id or secret access key is empty in %s",
+			ErrCredentialsNotFound, from)
+	}
+	return cred, nil
+}
+
+// StaticProvider 固定凭证
+type StaticProvider struct {
+	Cred *Credentials
+}
+
+func NewStaticProvider(appId int64, ak, sk string) *StaticProvider {
+	return &StaticProvider{
+		Cred: &Credentials{
+			AppId:           appId,
+			AccessKeyId:     ak,
+			SecretAccessKey: sk,
+		},
+	}
+}
+
+func (t *StaticProvider) Retrieve() (*Credentials, error) {
+	return validCredentials(t.Cred, "static provider")
+}
+
+// EnvProvider 从XASSET_APP_ID、XASSET_ACCESS_KEY_ID、XASSET_SECRET_ACCESS_KEY环境变量读取凭证
+type EnvProvider struct{}
+
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{}
+}
+
+func (t *EnvProvider) Retrieve() (*Credentials, error) {
+	cred := &Credentials{
+		AccessKeyId:     os.Getenv(EnvAccessKeyId),
+		SecretAccessKey: os.Getenv(EnvSecretAccessKey),
+	}
+	if appId := os.Getenv(EnvAppId); appId != "" {
+		id, err := strconv.ParseInt(appId, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("env %s must be an integer, got %q", EnvAppId, appId)
+		}
+		cred.AppId = id
+	}
+	return validCredentials(cred, "env")
+}
+
+// WarnLogger 告警日志接口，logs.LogDriver均实现了该接口
+type WarnLogger interface {
+	Warn(msg string, ctx ...interface{})
+}
+
+// FileProvider 从文件读取凭证，文件修改时间或大小变化后重新读取，用于不重启服务轮换AK/SK
+// 文件格式按扩展名为yaml/yml或json，字段为app_id、access_key_id、secret_access_key
+type FileProvider struct {
+	Path string
+	// 重新读取失败时输出告警，为nil时使用标准库log
+	Logger WarnLogger
+
+	lock    sync.Mutex
+	modTime time.Time
+	size    int64
+	cred    *Credentials
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{Path: path}
+}
+
+type credentialsFile struct {
+	AppId           int64  `yaml:"app_id" json:"app_id"`
+	AccessKeyId     string `yaml:"access_key_id" json:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key" json:"secret_access_key"`
+}
+
+// Retrieve 文件轮换过程中读取失败（如文件不存在或内容不完整）时返回上次读取成功的凭证并输出告警，
+// 从未读取成功时返回错误
+func (t *FileProvider) Retrieve() (*Credentials, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	cred, err := t.load()
+	if err == nil {
+		return cred, nil
+	}
+	if t.cred == nil {
+		return nil, err
+	}
+	if t.Logger != nil {
+		t.Logger.Warn("reload credentials file failed, use last credentials.[path:%s] [err:%v]", t.Path, err)
+	} else {
+		log.Printf("reload credentials file failed, use last credentials.[path:%s] [err:%v]", t.Path, err)
+	}
+	return t.cred, nil
+}
+
+func (t *FileProvider) load() (*Credentials, error) {
+	info, err := os.Stat(t.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: stat credentials file failed: %v", ErrCredentialsNotFound, err)
+	}
+	if t.cred != nil && info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return t.cred, nil
+	}
+
+	data, err := ioutil.ReadFile(t.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read credentials file failed: %v", ErrCredentialsNotFound, err)
+	}
+	var file credentialsFile
+	switch strings.ToLower(filepath.Ext(t.Path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse credentials file failed: %v", ErrCredentialsNotFound, err)
+	}
+	cred, err := validCredentials(&Credentials{
+		AppId:           file.AppId,
+		AccessKeyId:     file.AccessKeyId,
+		SecretAccessKey: file.SecretAccessKey,
+	}, t.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	t.cred, t.modTime, t.size = cred, info.ModTime(), info.Size()
+	return cred, nil
+}
+
+// ChainProvider 按顺序尝试多个凭证来源，返回第一个成功获取的凭证
+type ChainProvider struct {
+	Providers []CredentialsProvider
+}
+
+func NewChainProvider(providers ...CredentialsProvider) *ChainProvider {
+	return &ChainProvider{Providers: providers}
+}
+
+func (t *ChainProvider) Retrieve() (*Credentials, error) {
+	var errs []string
+	for _, p := range t.Providers {
+		cred, err := p.Retrieve()
+		if err == nil {
+			return cred, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("%w: no provider in chain succeeded: [%s]",
+		ErrCredentialsNotFound, strings.Join(errs, "; "))
+}
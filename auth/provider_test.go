@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnvProvider(t *testing.T) {
+	os.Setenv(EnvAppId, "100")
+	os.Setenv(EnvAccessKeyId, "ak-env")
+	os.Setenv(EnvSecretAccessKey, "sk-env")
+	defer func() {
+		os.Unsetenv(EnvAppId)
+		os.Unsetenv(EnvAccessKeyId)
+		os.Unsetenv(EnvSecretAccessKey)
+	}()
+
+	cred, err := NewEnvProvider().Retrieve()
+	if err != nil || cred.AppId != 100 || cred.AccessKeyId != "ak-env" || cred.SecretAccessKey != "sk-env" {
+		t.Fatalf("retrieve env credentials failed.cred:%v err:%v", cred, err)
+	}
+
+	os.Unsetenv(EnvSecretAccessKey)
+	if _, err := NewEnvProvider().Retrieve(); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("retrieve incomplete env credentials should fail.err:%v", err)
+	}
+}
+
+type warnCapture struct {
+	msgs []string
+}
+
+func (t *warnCapture) Warn(msg string, ctx ...interface{}) {
+	t.msgs = append(t.msgs, fmt.Sprintf(msg, ctx...))
+}
+
+func TestFileProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xasset-cred")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cred.json")
+	ioutil.WriteFile(file, []byte(`{"app_id":1,"access_key_id":"ak1","secret_access_key":"sk1"}`), 0600)
+
+	p := NewFileProvider(file)
+	cred, err := p.Retrieve()
+	if err != nil || cred.AccessKeyId != "ak1" || cred.SecretAccessKey != "sk1" {
+		t.Fatalf("retrieve file credentials failed.cred:%v err:%v", cred, err)
+	}
+
+	// 轮换凭证，修改时间后重新读取
+	ioutil.WriteFile(file, []byte(`{"app_id":1,"access_key_id":"ak2","secret_access_key":"sk2"}`), 0600)
+	later := time.Now().Add(time.Second)
+	os.Chtimes(file, later, later)
+	cred, err = p.Retrieve()
+	if err != nil || cred.AccessKeyId != "ak2" || cred.SecretAccessKey != "sk2" {
+		t.Errorf("retrieve rotated credentials failed.cred:%v err:%v", cred, err)
+	}
+
+	// 轮换过程中文件内容不完整或文件不存在时使用上次读取的凭证
+	logger := &warnCapture{}
+	p.Logger = logger
+	ioutil.WriteFile(file, []byte(`{"app_id":1,"access_key_id":"ak3",`), 0600)
+	later = later.Add(time.Second)
+	os.Chtimes(file, later, later)
+	cred, err = p.Retrieve()
+	if err != nil || cred.AccessKeyId != "ak2" || len(logger.msgs) != 1 {
+		t.Errorf("half written file should use last credentials.cred:%v err:%v", cred, err)
+	}
+	os.Remove(file)
+	if cred, err = p.Retrieve(); err != nil || cred.AccessKeyId != "ak2" {
+		t.Errorf("missing file should use last credentials.cred:%v err:%v", cred, err)
+	}
+	ioutil.WriteFile(file, []byte(`{"app_id":1,"access_key_id":"ak4","secret_access_key":"sk4"}`), 0600)
+	if cred, err = p.Retrieve(); err != nil || cred.AccessKeyId != "ak4" {
+		t.Errorf("retrieve credentials after rotation failed.cred:%v err:%v", cred, err)
+	}
+
+	badFile := filepath.Join(dir, "bad.json")
+	ioutil.WriteFile(badFile, []byte(`{"app_id":1,`), 0600)
+	if _, err = NewFileProvider(badFile).Retrieve(); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("never loaded file should fail.err:%v", err)
+	}
+
+	yamlFile := filepath.Join(dir, "cred.yaml")
+	ioutil.WriteFile(yamlFile, []byte("app_id: 2\naccess_key_id: ak3\nsecret_access_key: sk3\n"), 0600)
+	cred, err = NewFileProvider(yamlFile).Retrieve()
+	if err != nil || cred.AppId != 2 || cred.AccessKeyId != "ak3" {
+		t.Errorf("retrieve yaml credentials failed.cred:%v err:%v", cred, err)
+	}
+}
+
+func TestChainProvider(t *testing.T) {
+	chain := NewChainProvider(NewFileProvider("/not/exist/cred.json"), NewStaticProvider(1, "ak", "sk"))
+	cred, err := chain.Retrieve()
+	if err != nil || cred.AccessKeyId != "ak" {
+		t.Fatalf("retrieve chain credentials failed.cred:%v err:%v", cred, err)
+	}
+
+	chain = NewChainProvider(NewFileProvider("/not/exist/cred.json"), NewStaticProvider(1, "", ""))
+	if _, err := chain.Retrieve(); !errors.Is(err, ErrCredentialsNotFound) {
+		t.Errorf("retrieve empty chain should fail.err:%v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	NonceStoreCapacityDef = 100000
+	// 签名之外的key使用的默认有效期
+	ReplayWindowDef = time.Duration(VerifyMaxExpireSecondsDef+VerifyClockSkewSecondsDef) * time.Second
+)
+
+// NonceStore 记录有效期内已使用的签名或nonce，实现需要并发安全
+// 多实例部署时使用redis等共享存储实现，如SET key 1 NX PX ttl及DEL key
+type NonceStore interface {
+	// Add 记录key，ttl后过期。key在有效期内已存在时返回false
+	Add(key string, ttl time.Duration) (bool, error)
+	// Remove 删除key，请求处理失败时撤销记录，使客户端可以重试
+	Remove(key string) error
+}
+
+type nonceEntry struct {
+	key      string
+	expireAt time.Time
+}
+
+// MemoryNonceStore 单实例内存存储，按最近使用淘汰，超过容量时淘汰最久未使用的key
+// 容量需要大于有效期内的请求数，否则被淘汰的key可以再次使用
+type MemoryNonceStore struct {
+	capacity int
+
+	lock  sync.Mutex
+	items map[string]*list.Element
+	// 队首为最近使用
+	order *list.List
+	now   func() time.Time
+}
+
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	if capacity <= 0 {
+		capacity = NonceStoreCapacityDef
+	}
+	return &MemoryNonceStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (t *MemoryNonceStore) Add(key string, ttl time.Duration) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	if elem, ok := t.items[key]; ok {
+		entry := elem.Value.(*nonceEntry)
+		if entry.expireAt.After(now) {
+			t.order.MoveToFront(elem)
+			return false, nil
+		}
+		t.order.Remove(elem)
+		delete(t.items, key)
+	}
+
+	// 清理队尾过期的key，仍然超过容量时淘汰最久未使用的key
+	for elem := t.order.Back(); elem != nil; elem = t.order.Back() {
+		entry := elem.Value.(*nonceEntry)
+		if entry.expireAt.After(now) && t.order.Len() < t.capacity {
+			break
+		}
+		t.order.Remove(elem)
+		delete(t.items, entry.key)
+	}
+	t.items[key] = t.order.PushFront(&nonceEntry{key: key, expireAt: now.Add(ttl)})
+	return true, nil
+}
+
+func (t *MemoryNonceStore) Remove(key string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if elem, ok := t.items[key]; ok {
+		t.order.Remove(elem)
+		delete(t.items, key)
+	}
+	return nil
+}
+
+// Len 当前记录的key数量，包括尚未清理的过期key
+func (t *MemoryNonceStore) Len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.order.Len()
+}
+
+// ReplayGuard 拒绝有效期内重复使用的签名或nonce，并发安全
+// 可以在验签中间件中使用，也可以在测试替身服务中直接校验收到的请求
+type ReplayGuard struct {
+	store NonceStore
+	now   func() time.Time
+}
+
+// NewReplayGuard store为nil时使用默认容量的内存存储
+func NewReplayGuard(store NonceStore) *ReplayGuard {
+	if store == nil {
+		store = NewMemoryNonceStore(0)
+	}
+	return &ReplayGuard{store: store, now: time.Now}
+}
+
+// CheckSignature 校验Authorization header中的签名是否已使用，签名过期后不再记录
+func (t *ReplayGuard) CheckSignature(author string) error {
+	auth, err := parseAuthorization(author)
+	if err != nil {
+		return err
+	}
+	expireAt := auth.timestamp.Add(time.Duration(auth.expireSeconds) * time.Second)
+	_, err = t.checkSignature(auth, expireAt.Sub(t.now()))
+	return err
+}
+
+func (t *ReplayGuard) checkSignature(auth *authorization, ttl time.Duration) (string, error) {
+	key := "sign:" + auth.signature
+	return key, t.check(key, auth.accessKeyId, ttl)
+}
+
+// CheckNonce 校验同一AccessKeyId下的nonce是否已使用，ttl<=0时使用ReplayWindowDef
+func (t *ReplayGuard) CheckNonce(accessKeyId, nonce string, ttl time.Duration) error {
+	_, err := t.checkNonce(accessKeyId, nonce, ttl)
+	return err
+}
+
+func (t *ReplayGuard) checkNonce(accessKeyId, nonce string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = ReplayWindowDef
+	}
+	key := "nonce:" + accessKeyId + ":" + nonce
+	return key, t.check(key, accessKeyId, ttl)
+}
+
+func (t *ReplayGuard) check(key, ak string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	ok, err := t.store.Add(key, ttl)
+	if err != nil {
+		return &VerifyError{Err: ErrReplayStoreFailed, AccessKeyId: ak, Detail: err.Error()}
+	}
+	if !ok {
+		return &VerifyError{Err: ErrSignReplayed, AccessKeyId: ak}
+	}
+	return nil
+}
+
+// release 撤销记录的key，删除失败时客户端的重试仍会被当作重放拒绝
+func (t *ReplayGuard) release(keys []string) {
+	for _, key := range keys {
+		t.store.Remove(key)
+	}
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	store := NewMemoryNonceStore(2)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	if ok, _ := store.Add("a", time.Minute); !ok {
+		t.Fatalf("first add should succeed")
+	}
+	if ok, _ := store.Add("a", time.Minute); ok {
+		t.Errorf("duplicate key within ttl should be rejected")
+	}
+	// 过期后可以再次使用
+	now = now.Add(2 * time.Minute)
+	if ok, _ := store.Add("a", time.Minute); !ok {
+		t.Errorf("expired key should be accepted")
+	}
+
+	// 超过容量时淘汰最久未使用的key，重复添加视为使用
+	store.Add("b", time.Minute)
+	store.Add("a", time.Minute)
+	store.Add("c", time.Minute)
+	if store.Len() != 2 {
+		t.Errorf("store should not exceed capacity.len:%d", store.Len())
+	}
+	if ok, _ := store.Add("a", time.Minute); ok {
+		t.Errorf("recently used key should be kept")
+	}
+	if ok, _ := store.Add("b", time.Minute); !ok {
+		t.Errorf("least recently used key should be evicted")
+	}
+	// 删除后可以再次使用
+	store.Remove("b")
+	store.Remove("not-exist")
+	if ok, _ := store.Add("b", time.Minute); !ok || store.Len() != 2 {
+		t.Errorf("removed key should be accepted.len:%d", store.Len())
+	}
+}
+
+type failStore struct{}
+
+func (t failStore) Add(key string, ttl time.Duration) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func (t failStore) Remove(key string) error {
+	return errors.New("store unavailable")
+}
+
+func TestReplayGuard(t *testing.T) {
+	guard := NewReplayGuard(nil)
+	req := newSignedRequest(t, verifyCred, time.Now().Unix(), "")
+	author := req.Header.Get("Authorization")
+	if err := guard.CheckSignature(author); err != nil {
+		t.Fatalf("first check should pass.err:%v", err)
+	}
+	if err := guard.CheckSignature(author); !errors.Is(err, ErrSignReplayed) {
+		t.Errorf("replayed signature should be rejected.err:%v", err)
+	}
+	if err := guard.CheckSignature("bad"); !errors.Is(err, ErrSignFormat) {
+		t.Errorf("bad authorization should be rejected.err:%v", err)
+	}
+
+	if err := guard.CheckNonce("ak1", "123", 0); err != nil {
+		t.Fatalf("first nonce should pass.err:%v", err)
+	}
+	if err := guard.CheckNonce("ak1", "123", 0); !errors.Is(err, ErrSignReplayed) {
+		t.Errorf("replayed nonce should be rejected.err:%v", err)
+	}
+	if err := guard.CheckNonce("ak2", "123", 0); err != nil {
+		t.Errorf("nonce of other ak should pass.err:%v", err)
+	}
+
+	var ve *VerifyError
+	err := NewReplayGuard(failStore{}).CheckNonce("ak1", "123", 0)
+	if !errors.Is(err, ErrReplayStoreFailed) || !errors.As(err, &ve) || ve.HttpCode() != 503 {
+		t.Errorf("store error should fail closed.err:%v", err)
+	}
+}
+
+func TestVerifierNonceReplay(t *testing.T) {
+	// 多个实例共用存储
+	store := NewMemoryNonceStore(0)
+	cfg := NewVerifierConfig()
+	cfg.ReplayStore = store
+	v1 := NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+	v2 := NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+
+	now := time.Now().Unix()
+	if _, err := v1.Verify(newSignedRequest(t, verifyCred, now, "nonce=1&asset_id=1")); err != nil {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+	// 重新签名的请求签名不同，但nonce相同
+	req := newSignedRequest(t, verifyCred, now-1, "asset_id=1&nonce=1")
+	if _, err := v2.Verify(req); !errors.Is(err, ErrSignReplayed) {
+		t.Errorf("replayed nonce should be rejected.err:%v", err)
+	}
+	if _, err := v2.Verify(newSignedRequest(t, verifyCred, now, "nonce=2&asset_id=1")); err != nil {
+		t.Errorf("new nonce should pass.err:%v", err)
+	}
+
+	// 关闭nonce校验时只校验签名
+	cfg = NewVerifierConfig()
+	cfg.NonceField = ""
+	v := NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+	v.Verify(newSignedRequest(t, verifyCred, now, "nonce=1"))
+	if _, err := v.Verify(newSignedRequest(t, verifyCred, now-1, "nonce=1")); err != nil {
+		t.Errorf("nonce should not be checked.err:%v", err)
+	}
+}
+
+func TestVerifierNonceTtl(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryNonceStore(0)
+	store.now = func() time.Time { return now }
+	cfg := NewVerifierConfig()
+	cfg.MaxExpireSeconds = 7200
+	cfg.ReplayStore = store
+	v := NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+	v.now = func() time.Time { return now }
+
+	newReq := func(ts int64) *http.Request {
+		req := newSignedRequest(t, verifyCred, ts, "nonce=1")
+		req.Header.Set("Host", "xasset.test")
+		sign, _ := Sign(req, verifyCred, &SignOptions{HeadersToSign: DEFAULT_HEADERS_TO_SIGN,
+			Timestamp: ts, ExpireSeconds: 7200})
+		req.Header.Del("Host")
+		req.Header.Set("Authorization", sign)
+		return req
+	}
+	ts := now.Unix()
+	if _, err := v.Verify(newReq(ts)); err != nil {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+	// 超过默认窗口但签名仍在有效期内，nonce不能再次使用
+	now = now.Add(ReplayWindowDef + time.Minute)
+	if _, err := v.Verify(newReq(ts - 1)); !errors.Is(err, ErrSignReplayed) {
+		t.Errorf("nonce should be kept while sign is valid.err:%v", err)
+	}
+}
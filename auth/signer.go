@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,8 @@ var (
 	BCE_AUTH_VERSION        = "bce-auth-v1"
 	SIGN_JOINER             = "\n"
 	SIGN_HEADER_JOINER      = ";"
+	BCE_HEADER_PREFIX       = "x-bce-"
+	BCE_REQUEST_ID_HEADER   = "x-bce-request-id"
 	DEFAULT_EXPIRE_SECONDS  = 1800
 	DEFAULT_HEADERS_TO_SIGN = map[string]struct{}{
 		strings.ToLower("Host"):           {},
@@ -37,9 +40,22 @@ type Credentials struct {
 	SecretAccessKey string // secret access key to the service
 }
 
-func (t *Credentials) String() string {
+// MaskSecret 日志及String输出时隐藏敏感信息，空值保持为空便于排查漏配
+func MaskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "******"
+}
+
+// String 隐藏SecretAccessKey，避免打印到日志
+func (t Credentials) String() string {
 	return fmt.Sprintf("AppId:%d AccessKeyId:%s SecretAccessKey:%s",
-		t.AppId, t.AccessKeyId, t.SecretAccessKey)
+		t.AppId, t.AccessKeyId, MaskSecret(t.SecretAccessKey))
+}
+
+func (t Credentials) GoString() string {
+	return t.String()
 }
 
 func (t *SignOptions) String() string {
@@ -68,19 +84,22 @@ func Sign(req *http.Request, cred *Credentials, opt *SignOptions) (string, error
 	if opt.Timestamp > 0 {
 		signDate = util.FormatISO8601Date(opt.Timestamp)
 	}
-	if opt.HeadersToSign == nil {
-		opt.HeadersToSign = map[string]struct{}{"host": struct{}{}}
+	// opt可能被并发请求共用，默认值只写入局部变量
+	headersToSign := opt.HeadersToSign
+	if headersToSign == nil {
+		headersToSign = map[string]struct{}{"host": struct{}{}}
 	}
-	if opt.ExpireSeconds < 1 {
-		opt.ExpireSeconds = DEFAULT_EXPIRE_SECONDS
+	expireSeconds := opt.ExpireSeconds
+	if expireSeconds < 1 {
+		expireSeconds = DEFAULT_EXPIRE_SECONDS
 	}
 
 	// Prepare the canonical request components
-	signKeyInfo := fmt.Sprintf("%s/%s/%s/%d", BCE_AUTH_VERSION, accessKeyId, signDate, opt.ExpireSeconds)
+	signKeyInfo := fmt.Sprintf("%s/%s/%s/%d", BCE_AUTH_VERSION, accessKeyId, signDate, expireSeconds)
 	signKey := util.HmacSha256Hex(secretAccessKey, signKeyInfo)
 
 	// Generate signed head and signature
-	signedHeaders, signature := getSignature(req, opt, signKey)
+	signedHeaders, signature := getSignature(req, &SignOptions{HeadersToSign: withBceHeaders(req, headersToSign)}, signKey)
 
 	// Generate auth string and add to the reqeust header
 	authStr := signKeyInfo + "/" + signedHeaders + "/" + signature
@@ -89,13 +108,17 @@ func Sign(req *http.Request, cred *Credentials, opt *SignOptions) (string, error
 }
 
 // CheckSign - 校验签名
+// header中没有Authorization时校验PresignUrl生成的预签名地址，预签名地址只允许GET及HEAD请求
 func CheckSign(req *http.Request, cred *Credentials) error {
 	if req == nil || cred == nil {
 		return fmt.Errorf("param set error")
 	}
 
 	// 1.检验header中的Authorization格式
-	author := req.Header.Get("Authorization")
+	author, presigned := getAuthorization(req)
+	if presigned && !isPresignMethod(req.Method) {
+		return fmt.Errorf("presigned url only allows GET and HEAD.method:%s", req.Method)
+	}
 	authStrs := strings.Split(author, "/")
 	if len(authStrs) != 6 {
 		return fmt.Errorf("author format error.auth:%s", author)
@@ -109,7 +132,7 @@ func CheckSign(req *http.Request, cred *Credentials) error {
 	if err != nil {
 		return fmt.Errorf("author sign expiration set error.auth:%s", author)
 	}
-	if expirationPeriodInSeconds < 0 || expirationPeriodInSeconds > 3600 {
+	if expirationPeriodInSeconds < 0 || expirationPeriodInSeconds > PresignMaxExpireSeconds {
 		return fmt.Errorf("author sign expiration set error.auth:%s", author)
 	}
 	timestamp, err := util.ParseISO8601Date(authStrs[2])
@@ -154,33 +177,45 @@ func getCanonicalURIPath(path string) string {
 	return "/" + canonical_path
 }
 
-func getCanonicalQueryString(params map[string]string) string {
-	if len(params) == 0 {
+// getCanonicalQueryString 按bce-auth-v1规范生成规范查询串：解码rawQuery中的key及value后重新编码为key=value，
+// 值中的=及已转义的字符不会被截断或重复转义，重复的key各自保留，按字典序排序后以&连接，忽略authorization参数
+func getCanonicalQueryString(rawQuery string) string {
+	if len(rawQuery) == 0 {
 		return ""
 	}
 
-	result := make([]string, 0, len(params))
-	for k, v := range params {
-		if strings.ToLower(k) == strings.ToLower("Authorization") {
+	result := make([]string, 0)
+	for _, query := range strings.Split(rawQuery, "&") {
+		if len(query) == 0 {
 			continue
 		}
-		item := ""
-		if len(v) == 0 {
-			item = fmt.Sprintf("%s=", util.UriEncode(k, true))
-		} else {
-			item = fmt.Sprintf("%s=%s", util.UriEncode(k, true), util.UriEncode(v, true))
+		k, v := query, ""
+		if idx := strings.Index(query, "="); idx >= 0 {
+			k, v = query[:idx], query[idx+1:]
+		}
+		k, v = queryUnescape(k), queryUnescape(v)
+		if strings.ToLower(k) == strings.ToLower("Authorization") {
+			continue
 		}
-		result = append(result, item)
+		result = append(result, fmt.Sprintf("%s=%s", util.UriEncode(k, true), util.UriEncode(v, true)))
 	}
 	sort.Strings(result)
 	return strings.Join(result, "&")
 }
 
+// queryUnescape 解码失败时按原样使用
+func queryUnescape(s string) string {
+	if d, err := url.QueryUnescape(s); err == nil {
+		return d
+	}
+	return s
+}
+
 func getCanonicalHeaders(headers map[string]string, headersToSign map[string]struct{}) (string, []string) {
 	canonicalHeaders := make([]string, 0, len(headers))
 	signHeaders := make([]string, 0, len(headersToSign))
 	for k, v := range headers {
-		headKey := strings.ToLower(k)
+		headKey := strings.ToLower(strings.TrimSpace(k))
 		if headKey == strings.ToLower("Authorization") {
 			continue
 		}
@@ -197,27 +232,39 @@ func getCanonicalHeaders(headers map[string]string, headersToSign map[string]str
 	return strings.Join(canonicalHeaders, SIGN_JOINER), signHeaders
 }
 
-func getSignature(req *http.Request, opt *SignOptions, signingKey string) (string, string) {
-	canonicalUri := getCanonicalURIPath(req.URL.Path)
-	queryParams := make(map[string]string, 0)
-	for _, query := range strings.Split(req.URL.RawQuery, "&") {
-		if len(query) == 0 {
-			break
-		}
-		param := strings.Split(query, "=")
-		if len(param) == 1 {
-			queryParams[param[0]] = ""
-		} else {
-			queryParams[param[0]] = param[1]
+// withBceHeaders 与其他bce-auth-v1实现一致，签名时自动加入除x-bce-request-id外的x-bce-前缀header
+func withBceHeaders(req *http.Request, headersToSign map[string]struct{}) map[string]struct{} {
+	result := make(map[string]struct{}, len(headersToSign))
+	for k := range headersToSign {
+		result[k] = struct{}{}
+	}
+	for k := range req.Header {
+		headKey := strings.ToLower(k)
+		if strings.HasPrefix(headKey, BCE_HEADER_PREFIX) && headKey != BCE_REQUEST_ID_HEADER {
+			result[headKey] = struct{}{}
 		}
 	}
-	canonicalQueryString := getCanonicalQueryString(queryParams)
+	return result
+}
 
-	headerParams := make(map[string]string, 0)
+// canonicalRequest 生成规范请求，返回参与签名的header及规范请求串
+// header中没有Host时使用实际发送的Host，服务端收到的请求可以直接校验
+func canonicalRequest(req *http.Request, headersToSign map[string]struct{}) (string, string) {
+	canonicalUri := getCanonicalURIPath(req.URL.Path)
+	canonicalQueryString := getCanonicalQueryString(req.URL.RawQuery)
+
+	headerParams := make(map[string]string, len(req.Header)+1)
 	for k, v := range req.Header {
 		headerParams[k] = strings.Join(v, ";")
 	}
-	canonicalHeaders, signedHeadersArr := getCanonicalHeaders(headerParams, opt.HeadersToSign)
+	if req.Header.Get("Host") == "" {
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		headerParams["Host"] = host
+	}
+	canonicalHeaders, signedHeadersArr := getCanonicalHeaders(headerParams, headersToSign)
 
 	// Generate signed headers string
 	signedHeaders := ""
@@ -226,8 +273,11 @@ func getSignature(req *http.Request, opt *SignOptions, signingKey string) (strin
 		signedHeaders = strings.Join(signedHeadersArr, SIGN_HEADER_JOINER)
 	}
 
-	// Generate signature
 	canonicalParts := []string{req.Method, canonicalUri, canonicalQueryString, canonicalHeaders}
-	canonicalReq := strings.Join(canonicalParts, SIGN_JOINER)
+	return signedHeaders, strings.Join(canonicalParts, SIGN_JOINER)
+}
+
+func getSignature(req *http.Request, opt *SignOptions, signingKey string) (string, string) {
+	signedHeaders, canonicalReq := canonicalRequest(req, opt.HeadersToSign)
 	return signedHeaders, util.HmacSha256Hex(signingKey, canonicalReq)
 }
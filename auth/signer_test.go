@@ -3,9 +3,13 @@ package auth
 import (
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/baidubce/bce-sdk-go/util"
 )
 
 var cred = &Credentials{
@@ -110,3 +114,138 @@ func TestCheckSign(t *testing.T) {
 		}
 	}
 }
+
+// 规范请求及签名的标准用例，签名由独立的bce-auth-v1实现按规范计算
+// AK:ak-golden SK:sk-golden 签名时间:2015-04-27T08:23:49Z 有效期:1800
+var signGoldenVectors = []struct {
+	name      string
+	method    string
+	path      string
+	rawQuery  string
+	headers   map[string]string
+	toSign    []string
+	canonical string
+	auth      string
+}{
+	{
+		name:   "sdk post",
+		method: "POST", path: "/xasset/horae/v1/query",
+		headers: map[string]string{
+			"Host":         "120.48.16.137",
+			"Content-Type": "application/x-www-form-urlencoded;charset=utf-8",
+			"Content-Md5":  "f1e7a3d0fe6e4a9c8d2b8c0a9f4f5e11",
+		},
+		toSign:    []string{"host", "content-type", "content-md5"},
+		canonical: "POST\n/xasset/horae/v1/query\n\ncontent-md5:f1e7a3d0fe6e4a9c8d2b8c0a9f4f5e11\ncontent-type:application%2Fx-www-form-urlencoded%3Bcharset%3Dutf-8\nhost:120.48.16.137",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/content-md5;content-type;host/daf4e458d83dd51658dc97b7e542c536b5620a6ec8e98decc94be73aeb86a639",
+	},
+	{
+		name:   "sorted query",
+		method: "GET", path: "/v1/asset", rawQuery: "b=2&a=1",
+		canonical: "GET\n/v1/asset\na=1&b=2\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/5fd7f6e1103006c0d48ad0e10c473479bddfdbe44f83f5720264260cd8eb89b7",
+	},
+	{
+		name:   "value contains equal sign",
+		method: "GET", path: "/v1/asset", rawQuery: "token=abc==&x=1",
+		canonical: "GET\n/v1/asset\ntoken=abc%3D%3D&x=1\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/12cede4a4c590f54251310ccd4977659cedfbbd8b5b2520ab022cf982d53ce64",
+	},
+	{
+		name:   "escaped key and empty value",
+		method: "GET", path: "/v1/asset", rawQuery: "na%20me=%E6%9E%97&age=&flag",
+		canonical: "GET\n/v1/asset\nage=&flag=&na%20me=%E6%9E%97\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/0446a03f0752c32ac51c1a91370198e7419544b045396758308c603fb7b4706f",
+	},
+	{
+		name:   "repeated key",
+		method: "GET", path: "/v1/asset", rawQuery: "id=2&id=1&id=10",
+		canonical: "GET\n/v1/asset\nid=1&id=10&id=2\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/2f041771ca50f568926598961368b29d2d1c4dae67ef38022301b444748baabb",
+	},
+	{
+		name:   "authorization param ignored",
+		method: "GET", path: "/v1/asset", rawQuery: "authorization=xxx&Authorization=yyy&a=1",
+		canonical: "GET\n/v1/asset\na=1\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/aeaa9c00a1d08b651d0274b23db467882ab87b0aa46617ab91e4883ad57c49af",
+	},
+	{
+		name:   "path and plus sign",
+		method: "GET", path: "/v1/a b/林~.txt", rawQuery: "q=a+b&r=%2B",
+		canonical: "GET\n/v1/a%20b/%E6%9E%97~.txt\nq=a%20b&r=%2B\nhost:xasset.test",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/host/88546c781ef92466b99fd9e8acfb0df11bcd799e02eb8f20599cd87d478e0050",
+	},
+	{
+		name:   "trimmed and x-bce headers",
+		method: "PUT", path: "/v1/test",
+		headers: map[string]string{
+			"Content-Type":     "  text/plain ",
+			"X-Bce-Date":       "2015-04-27T08:23:49Z ",
+			"X-Bce-Request-Id": "rid",
+		},
+		toSign:    []string{"content-type"},
+		canonical: "PUT\n/v1/test\n\ncontent-type:text%2Fplain\nhost:xasset.test\nx-bce-date:2015-04-27T08%3A23%3A49Z",
+		auth:      "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800/content-type;host;x-bce-date/78805116f1880cef8c27400b6ca3eba2c03c96e9d357c77279b62b5155e50378",
+	},
+}
+
+func TestSignGoldenVectors(t *testing.T) {
+	goldenCred := &Credentials{AccessKeyId: "ak-golden", SecretAccessKey: "sk-golden"}
+	for _, v := range signGoldenVectors {
+		req := &http.Request{
+			Method: v.method,
+			URL:    &url.URL{Scheme: "http", Host: "xasset.test", Path: v.path, RawQuery: v.rawQuery},
+			Host:   "xasset.test",
+			Header: http.Header{},
+		}
+		for k, val := range v.headers {
+			req.Header.Set(k, val)
+		}
+		toSign := map[string]struct{}{}
+		for _, h := range v.toSign {
+			toSign[h] = struct{}{}
+		}
+
+		_, canonical := canonicalRequest(req, withBceHeaders(req, toSign))
+		if canonical != v.canonical {
+			t.Errorf("canonical request not match.[case:%s]\n%s\n%s", v.name, canonical, v.canonical)
+		}
+		sign, err := Sign(req, goldenCred, &SignOptions{HeadersToSign: toSign, Timestamp: 1430123029, ExpireSeconds: 1800})
+		if err != nil || sign != v.auth {
+			t.Errorf("sign not match.[case:%s] [sign:%s] [err:%v]", v.name, sign, err)
+		}
+
+		// 服务端按Authorization中的签名头域校验
+		req.Header.Set("Authorization", sign)
+		signed := &SignOptions{HeadersToSign: map[string]struct{}{}}
+		for _, h := range strings.Split(strings.Split(sign, "/")[4], ";") {
+			signed.HeadersToSign[h] = struct{}{}
+		}
+		signingKey := util.HmacSha256Hex(goldenCred.SecretAccessKey, "bce-auth-v1/ak-golden/2015-04-27T08:23:49Z/1800")
+		if _, sig := getSignature(req, signed, signingKey); !strings.HasSuffix(sign, sig) {
+			t.Errorf("verify golden signature failed.[case:%s]", v.name)
+		}
+	}
+}
+
+func TestSignSharedOption(t *testing.T) {
+	// 并发请求共用同一个SignOptions，签名不能修改opt
+	opt := &SignOptions{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("POST", "http://xasset.test/v1/query", nil)
+			req.Header.Set("Host", "xasset.test")
+			sign, err := Sign(req, cred, opt)
+			if err != nil || !strings.Contains(sign, "/1800/host/") {
+				t.Errorf("sign with default option failed.sign:%s err:%v", sign, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if opt.HeadersToSign != nil || opt.ExpireSeconds != 0 {
+		t.Errorf("sign should not modify option.opt:%v", opt)
+	}
+}
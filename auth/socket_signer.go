@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	SocketSignerTimeoutDef = 5 * time.Second
+	// 签名服务接口，请求通过unix socket发送，host部分不生效
+	SocketSignerKeyUrl  = "http://signer/v1/key"
+	SocketSignerSignUrl = "http://signer/v1/sign"
+	// 签名服务响应体最大长度
+	socketSignerMaxRespBytes = 1 << 16
+)
+
+var (
+	ErrSignerParamInvalid = errors.New("signer param invalid")
+	ErrSignerDaemonFailed = errors.New("signer daemon request failed")
+)
+
+// SocketSigner 参考实现，通过unix socket访问本机签名服务，私钥只保存在签名服务中。
+// 协议为http+json：
+//
+//	GET  /v1/key?address=xxx          => {"address":"xxx","public_key":"json公钥"}
+//	POST /v1/sign {"address":"xxx","msg":"base64原始消息"} => {"signature":"hex签名"}
+//
+// 签名算法需要与XassetSignECDSA一致，非200响应返回{"error":"错误信息"}
+type SocketSigner struct {
+	socketPath string
+	address    string
+	publicKey  string
+	client     *http.Client
+}
+
+type socketKeyResp struct {
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+type socketSignReq struct {
+	Address string `json:"address"`
+	Msg     string `json:"msg"`
+}
+
+type socketSignResp struct {
+	Signature string `json:"signature"`
+}
+
+// NewSocketSigner 创建时从签名服务获取地址对应的公钥，timeout<=0时使用SocketSignerTimeoutDef
+// timeout为单次访问签名服务的超时，Sign的ctx更早取消或超时时以ctx为准
+func NewSocketSigner(socketPath, address string, timeout time.Duration) (*SocketSigner, error) {
+	if socketPath == "" || address == "" {
+		return nil, ErrSignerParamInvalid
+	}
+	if timeout <= 0 {
+		timeout = SocketSignerTimeoutDef
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	signer := &SocketSigner{
+		socketPath: socketPath,
+		address:    address,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+
+	var resp socketKeyResp
+	if err := signer.do(context.Background(), "GET", SocketSignerKeyUrl+"?address="+url.QueryEscape(address), nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Address != address || resp.PublicKey == "" {
+		return nil, fmt.Errorf("%w. [address:%s] [resp_address:%s] [err:public key not found]",
+			ErrSignerDaemonFailed, address, resp.Address)
+	}
+	signer.publicKey = resp.PublicKey
+	return signer, nil
+}
+
+func (t *SocketSigner) GetAddress() string {
+	return t.address
+}
+
+func (t *SocketSigner) GetPublicKey() string {
+	return t.publicKey
+}
+
+func (t *SocketSigner) Sign(ctx context.Context, msg []byte) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	body, err := json.Marshal(&socketSignReq{
+		Address: t.address,
+		Msg:     base64.StdEncoding.EncodeToString(msg),
+	})
+	if err != nil {
+		return "", err
+	}
+	var resp socketSignResp
+	if err := t.do(ctx, "POST", SocketSignerSignUrl, body, &resp); err != nil {
+		return "", err
+	}
+	if resp.Signature == "" {
+		return "", fmt.Errorf("%w. [address:%s] [err:empty signature]", ErrSignerDaemonFailed, t.address)
+	}
+	return resp.Signature, nil
+}
+
+func (t *SocketSigner) String() string {
+	return fmt.Sprintf("{SocketPath:%s Address:%s}", t.socketPath, t.address)
+}
+
+func (t *SocketSigner) do(ctx context.Context, method, reqUrl string, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w. [socket:%s] [err:%v]", ErrSignerDaemonFailed, t.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, socketSignerMaxRespBytes))
+	if err != nil {
+		return fmt.Errorf("%w. [socket:%s] [err:%v]", ErrSignerDaemonFailed, t.socketPath, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal(respBody, &errResp)
+		return fmt.Errorf("%w. [socket:%s] [status:%d] [err:%s]",
+			ErrSignerDaemonFailed, t.socketPath, resp.StatusCode, errResp.Error)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("%w. [socket:%s] [err:%v]", ErrSignerDaemonFailed, t.socketPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startSignDaemon 模拟本机签名服务，私钥只保存在服务中
+func startSignDaemon(t *testing.T, acc *Account) (string, func()) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	socketPath := filepath.Join(dir, "signer.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("listen unix socket failed.err:%v", err)
+	}
+
+	writeJson := func(w http.ResponseWriter, code int, v interface{}) {
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(v)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/key", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("address") != acc.Address {
+			writeJson(w, 404, map[string]string{"error": "key not found"})
+			return
+		}
+		writeJson(w, 200, map[string]string{"address": acc.Address, "public_key": acc.PublicKey})
+	})
+	mux.HandleFunc("/v1/sign", func(w http.ResponseWriter, r *http.Request) {
+		var req socketSignReq
+		json.NewDecoder(r.Body).Decode(&req)
+		msg, err := base64.StdEncoding.DecodeString(req.Msg)
+		if err != nil || req.Address != acc.Address {
+			writeJson(w, 400, map[string]string{"error": "bad request"})
+			return
+		}
+		sign, _ := acc.Sign(r.Context(), msg)
+		writeJson(w, 200, map[string]string{"signature": sign})
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	return socketPath, func() {
+		srv.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAccountSigner(t *testing.T) {
+	acc, err := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangCN)
+	if err != nil {
+		t.Fatalf("new account failed.err:%v", err)
+	}
+	var signer Signer = acc
+	if signer.GetAddress() != acc.Address || signer.GetPublicKey() != acc.PublicKey {
+		t.Errorf("account signer key not match")
+	}
+	sign, err := signer.Sign(context.Background(), []byte("hello world"))
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, err := XassetVerifyECDSA(acc.PublicKey, sign, []byte("hello world")); !ok || err != nil {
+		t.Errorf("verify sign failed.err:%v", err)
+	}
+}
+
+func TestSocketSigner(t *testing.T) {
+	acc, err := NewXchainEcdsaAccount(MnemStrgthMedium, MnemLangCN)
+	if err != nil {
+		t.Fatalf("new account failed.err:%v", err)
+	}
+	socketPath, closeFn := startSignDaemon(t, acc)
+	defer closeFn()
+
+	signer, err := NewSocketSigner(socketPath, acc.Address, 0)
+	if err != nil {
+		t.Fatalf("new socket signer failed.err:%v", err)
+	}
+	if signer.GetAddress() != acc.Address || signer.GetPublicKey() != acc.PublicKey {
+		t.Errorf("socket signer key not match")
+	}
+	sign, err := signer.Sign(context.Background(), []byte("123456"))
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	if ok, err := XassetVerifyECDSA(acc.PublicKey, sign, []byte("123456")); !ok || err != nil {
+		t.Errorf("verify sign failed.err:%v", err)
+	}
+
+	if _, err := NewSocketSigner(socketPath, "unknown", 0); !errors.Is(err, ErrSignerDaemonFailed) {
+		t.Errorf("unknown address should fail.err:%v", err)
+	}
+	if _, err := NewSocketSigner("", acc.Address, 0); !errors.Is(err, ErrSignerParamInvalid) {
+		t.Errorf("empty socket path should fail.err:%v", err)
+	}
+	closeFn()
+	if _, err := signer.Sign(context.Background(), []byte("123456")); !errors.Is(err, ErrSignerDaemonFailed) {
+		t.Errorf("sign should fail after daemon closed.err:%v", err)
+	}
+}
+
+func TestSocketSignerCtx(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "signer.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen unix socket failed.err:%v", err)
+	}
+	// 签名服务卡住，直到客户端断开
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/key", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"address": "addr", "public_key": "pkey"})
+	})
+	mux.HandleFunc("/v1/sign", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	signer, err := NewSocketSigner(socketPath, "addr", 0)
+	if err != nil {
+		t.Fatalf("new socket signer failed.err:%v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := signer.Sign(ctx, []byte("123456")); !errors.Is(err, ErrSignerDaemonFailed) {
+		t.Errorf("sign should fail when ctx done.err:%v", err)
+	}
+	if cost := time.Since(start); cost >= SocketSignerTimeoutDef {
+		t.Errorf("sign should return when ctx done.cost:%v", cost)
+	}
+}
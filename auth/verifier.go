@@ -0,0 +1,448 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baidubce/bce-sdk-go/util"
+)
+
+const (
+	VerifyMaxExpireSecondsDef = 3600
+	VerifyClockSkewSecondsDef = 300
+	VerifyMaxBodyBytesDef     = 10 << 20
+)
+
+// 验签失败类型，可以通过errors.Is判断
+var (
+	ErrSignMissing         = errors.New("authorization missing")
+	ErrSignFormat          = errors.New("authorization format error")
+	ErrSignExpired         = errors.New("signature expired")
+	ErrSignClockSkew       = errors.New("signature timestamp too far in the future")
+	ErrAccessKeyNotFound   = errors.New("access key id not found")
+	ErrSignMismatch        = errors.New("signature mismatch")
+	ErrContentMd5Missing   = errors.New("content md5 missing or not signed")
+	ErrContentMd5Mismatch  = errors.New("content md5 mismatch")
+	ErrRequestBodyTooLarge = errors.New("request body too large")
+	ErrSignReplayed        = errors.New("signature or nonce replayed")
+	ErrReplayStoreFailed   = errors.New("replay store failed")
+)
+
+// VerifyError 验签失败时返回的错误，Err为上面的失败类型
+type VerifyError struct {
+	Err error
+	// 请求中的AccessKeyId，解析Authorization失败时为空
+	AccessKeyId string
+	// 失败详情，不包含密钥及签名
+	Detail string
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("%v.[ak:%s] [detail:%s]", e.Err, e.AccessKeyId, e.Detail)
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Err
+}
+
+// HttpCode 失败对应的http状态码，请求体相关的错误为400，其他为401
+func (e *VerifyError) HttpCode() int {
+	switch e.Err {
+	case ErrContentMd5Mismatch:
+		return http.StatusBadRequest
+	case ErrRequestBodyTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrReplayStoreFailed:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusUnauthorized
+}
+
+// CredentialsLookup 按AccessKeyId查询凭证，实现需要并发安全
+// 未找到时返回nil凭证或错误
+type CredentialsLookup interface {
+	LookupCredentials(accessKeyId string) (*Credentials, error)
+}
+
+// CredentialsLookupFunc 函数形式的CredentialsLookup
+type CredentialsLookupFunc func(accessKeyId string) (*Credentials, error)
+
+func (fn CredentialsLookupFunc) LookupCredentials(accessKeyId string) (*Credentials, error) {
+	return fn(accessKeyId)
+}
+
+// NewCredentialsLookup 使用固定的凭证列表
+func NewCredentialsLookup(creds ...*Credentials) CredentialsLookup {
+	m := make(map[string]*Credentials, len(creds))
+	for _, cred := range creds {
+		if cred != nil {
+			m[cred.AccessKeyId] = cred
+		}
+	}
+	return CredentialsLookupFunc(func(accessKeyId string) (*Credentials, error) {
+		return m[accessKeyId], nil
+	})
+}
+
+// VerifierConfig 验签配置
+type VerifierConfig struct {
+	// 允许的最大签名有效期
+	MaxExpireSeconds int
+	// 允许的客户端与服务端时钟偏差，签名时间晚于当前时间或过期时间早于当前时间时在该范围内仍然有效
+	ClockSkewSeconds int
+	// 要求有请求体的请求必须携带并签名Content-Md5，请求携带了Content-Md5时总会校验
+	RequireContentMd5 bool
+	// 校验Content-Md5时读取请求体的上限
+	MaxBodyBytes int64
+	// 拒绝有效期内重复使用的签名，签名精确到秒，同一秒内内容相同的请求签名也相同，
+	// 会被当作重放拒绝。Middleware在handler返回非2xx状态码时撤销记录，允许客户端重试
+	RejectReplay bool
+	// 记录已使用签名及nonce的存储，为nil时使用内存存储，多实例部署时需要使用共享存储
+	ReplayStore NonceStore
+	// 请求参数中nonce的字段名，从query及表单请求体中读取，同一AccessKeyId下的nonce
+	// 在签名有效期及时钟偏差内只能使用一次。为空时不校验nonce
+	NonceField string
+	// 接受PresignUrl生成的预签名地址，只允许GET及HEAD请求。预签名地址在有效期内可以重复访问，
+	// 不校验签名重放
+	AllowPresignedUrl bool
+}
+
+func NewVerifierConfig() *VerifierConfig {
+	return &VerifierConfig{
+		MaxExpireSeconds:  VerifyMaxExpireSecondsDef,
+		ClockSkewSeconds:  VerifyClockSkewSecondsDef,
+		RequireContentMd5: true,
+		MaxBodyBytes:      VerifyMaxBodyBytesDef,
+		RejectReplay:      true,
+		NonceField:        "nonce",
+	}
+}
+
+// Verifier 校验bce-auth-v1签名，并发安全
+type Verifier struct {
+	lookup CredentialsLookup
+	cfg    *VerifierConfig
+	replay *ReplayGuard
+	now    func() time.Time
+}
+
+// NewVerifier 创建验签器，cfg为nil时使用默认配置
+func NewVerifier(lookup CredentialsLookup, cfg *VerifierConfig) *Verifier {
+	if cfg == nil {
+		cfg = NewVerifierConfig()
+	}
+	return &Verifier{
+		lookup: lookup,
+		cfg:    cfg,
+		replay: NewReplayGuard(cfg.ReplayStore),
+		now:    time.Now,
+	}
+}
+
+// authorization 解析后的Authorization header
+type authorization struct {
+	accessKeyId   string
+	timestamp     time.Time
+	expireSeconds int64
+	signedHeaders map[string]struct{}
+	signature     string
+	// 签名密钥的派生信息，即前四段
+	signKeyInfo string
+}
+
+func parseAuthorization(author string) (*authorization, error) {
+	if author == "" {
+		return nil, &VerifyError{Err: ErrSignMissing}
+	}
+	strs := strings.Split(author, "/")
+	if len(strs) != 6 || strs[0] != BCE_AUTH_VERSION {
+		return nil, &VerifyError{Err: ErrSignFormat, Detail: "authorization must have 6 parts of bce-auth-v1"}
+	}
+	auth := &authorization{
+		accessKeyId:   strs[1],
+		signedHeaders: map[string]struct{}{},
+		signature:     strs[5],
+		signKeyInfo:   strings.Join(strs[0:4], "/"),
+	}
+	ts, err := util.ParseISO8601Date(strs[2])
+	if err != nil {
+		return nil, &VerifyError{Err: ErrSignFormat, AccessKeyId: auth.accessKeyId, Detail: "timestamp invalid"}
+	}
+	auth.timestamp = ts
+	auth.expireSeconds, err = strconv.ParseInt(strs[3], 10, 32)
+	if err != nil {
+		return nil, &VerifyError{Err: ErrSignFormat, AccessKeyId: auth.accessKeyId, Detail: "expiration invalid"}
+	}
+	for _, h := range strings.Split(strs[4], SIGN_HEADER_JOINER) {
+		if h != "" {
+			auth.signedHeaders[h] = struct{}{}
+		}
+	}
+	return auth, nil
+}
+
+// Verify 校验请求签名，成功时返回请求方的凭证
+// 校验Content-Md5时会读取请求体，读取后请求体可以再次读取
+// 开启RejectReplay时验签通过即记录签名及nonce，需要在处理失败后允许客户端重试时使用Middleware
+func (t *Verifier) Verify(req *http.Request) (*Credentials, error) {
+	cred, _, err := t.verify(req)
+	return cred, err
+}
+
+// verify 校验请求签名，同时返回记录的签名及nonce的key
+func (t *Verifier) verify(req *http.Request) (*Credentials, []string, error) {
+	cred, keys, err := t.verifySign(req)
+	if err != nil {
+		t.replay.release(keys)
+		return nil, nil, err
+	}
+	return cred, keys, nil
+}
+
+func (t *Verifier) verifySign(req *http.Request) (*Credentials, []string, error) {
+	if req == nil {
+		return nil, nil, &VerifyError{Err: ErrSignMissing}
+	}
+	author, presigned := getAuthorization(req)
+	auth, err := parseAuthorization(author)
+	if err != nil {
+		return nil, nil, err
+	}
+	ak := auth.accessKeyId
+	if presigned && (!t.cfg.AllowPresignedUrl || !isPresignMethod(req.Method)) {
+		return nil, nil, &VerifyError{Err: ErrSignFormat, AccessKeyId: ak,
+			Detail: "presigned url not allowed for " + req.Method}
+	}
+
+	// 1.校验有效期及时钟偏差
+	if auth.expireSeconds <= 0 || auth.expireSeconds > int64(t.cfg.MaxExpireSeconds) {
+		return nil, nil, &VerifyError{Err: ErrSignFormat, AccessKeyId: ak,
+			Detail: fmt.Sprintf("expiration must between 1 and %d", t.cfg.MaxExpireSeconds)}
+	}
+	now := t.now()
+	skew := time.Duration(t.cfg.ClockSkewSeconds) * time.Second
+	if auth.timestamp.Sub(now) > skew {
+		return nil, nil, &VerifyError{Err: ErrSignClockSkew, AccessKeyId: ak,
+			Detail: fmt.Sprintf("sign time:%s", auth.timestamp.Format(time.RFC3339))}
+	}
+	expireAt := auth.timestamp.Add(time.Duration(auth.expireSeconds) * time.Second)
+	if now.Sub(expireAt) > skew {
+		return nil, nil, &VerifyError{Err: ErrSignExpired, AccessKeyId: ak,
+			Detail: fmt.Sprintf("expired at:%s", expireAt.Format(time.RFC3339))}
+	}
+
+	// 2.校验签名头域
+	if _, ok := auth.signedHeaders["host"]; !ok {
+		return nil, nil, &VerifyError{Err: ErrSignFormat, AccessKeyId: ak, Detail: "host not signed"}
+	}
+	if err := t.requireContentMd5(req, auth); err != nil {
+		return nil, nil, err
+	}
+
+	// 3.查询凭证并校验签名摘要，签名只覆盖Content-Md5 header，签名通过后才读取请求体
+	if t.lookup == nil {
+		return nil, nil, &VerifyError{Err: ErrAccessKeyNotFound, AccessKeyId: ak, Detail: "no credentials lookup"}
+	}
+	cred, err := t.lookup.LookupCredentials(ak)
+	if err != nil || cred == nil {
+		return nil, nil, &VerifyError{Err: ErrAccessKeyNotFound, AccessKeyId: ak, Detail: fmt.Sprint(err)}
+	}
+	signingKey := util.HmacSha256Hex(cred.SecretAccessKey, auth.signKeyInfo)
+	_, signature := getSignature(req, &SignOptions{HeadersToSign: auth.signedHeaders}, signingKey)
+	if !hmac.Equal([]byte(signature), []byte(auth.signature)) {
+		return nil, nil, &VerifyError{Err: ErrSignMismatch, AccessKeyId: ak}
+	}
+	if err := t.checkContentMd5(req, auth); err != nil {
+		return nil, nil, err
+	}
+
+	// 4.拒绝重放，签名及nonce在签名有效期及时钟偏差内只能使用一次
+	var keys []string
+	if t.cfg.RejectReplay {
+		ttl := expireAt.Add(skew).Sub(now)
+		// 预签名地址允许重复访问，只校验nonce
+		if !presigned {
+			key, err := t.replay.checkSignature(auth, ttl)
+			if err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, key)
+		}
+		if nonce := t.nonce(req, auth); nonce != "" {
+			key, err := t.replay.checkNonce(ak, nonce, ttl)
+			if err != nil {
+				// 撤销已记录的签名
+				return nil, keys, err
+			}
+			keys = append(keys, key)
+		}
+	}
+	return cred, keys, nil
+}
+
+// nonce 从query及表单请求体中读取nonce
+func (t *Verifier) nonce(req *http.Request, auth *authorization) string {
+	if t.cfg.NonceField == "" {
+		return ""
+	}
+	if nonce := req.URL.Query().Get(t.cfg.NonceField); nonce != "" {
+		return nonce
+	}
+	if !strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return ""
+	}
+	body, err := bufferBody(req, t.cfg.MaxBodyBytes)
+	if err != nil {
+		return ""
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return ""
+	}
+	return form.Get(t.cfg.NonceField)
+}
+
+// bufferBody 读取请求体并替换为可以再次读取的副本，超过max时返回ErrRequestBodyTooLarge
+func bufferBody(req *http.Request, max int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, max+1))
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > max {
+		return nil, ErrRequestBodyTooLarge
+	}
+	return body, nil
+}
+
+// requireContentMd5 开启RequireContentMd5时检查有请求体的请求携带并签名了Content-Md5，不读取请求体
+func (t *Verifier) requireContentMd5(req *http.Request, auth *authorization) error {
+	if !t.cfg.RequireContentMd5 || req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+		return nil
+	}
+	if _, signed := auth.signedHeaders["content-md5"]; !signed || req.Header.Get("Content-Md5") == "" {
+		return &VerifyError{Err: ErrContentMd5Missing, AccessKeyId: auth.accessKeyId}
+	}
+	return nil
+}
+
+// checkContentMd5 重新计算请求体的md5，兼容SDK使用的hex编码及RFC 1864的base64编码
+// 需要在签名校验通过后调用，避免未认证的请求使服务端读取请求体
+func (t *Verifier) checkContentMd5(req *http.Request, auth *authorization) error {
+	expect := req.Header.Get("Content-Md5")
+	if expect == "" {
+		return nil
+	}
+
+	body, err := bufferBody(req, t.cfg.MaxBodyBytes)
+	if err == ErrRequestBodyTooLarge {
+		return &VerifyError{Err: ErrRequestBodyTooLarge, AccessKeyId: auth.accessKeyId}
+	}
+	if err != nil {
+		return &VerifyError{Err: ErrContentMd5Mismatch, AccessKeyId: auth.accessKeyId,
+			Detail: fmt.Sprintf("read body failed:%v", err)}
+	}
+	sum := md5.Sum(body)
+	if !strings.EqualFold(expect, hex.EncodeToString(sum[:])) &&
+		expect != base64.StdEncoding.EncodeToString(sum[:]) {
+		return &VerifyError{Err: ErrContentMd5Mismatch, AccessKeyId: auth.accessKeyId}
+	}
+	return nil
+}
+
+type credentialsKey struct{}
+
+// CredentialsFromContext 返回验签中间件存入ctx的请求方凭证
+func CredentialsFromContext(ctx context.Context) (*Credentials, bool) {
+	cred, ok := ctx.Value(credentialsKey{}).(*Credentials)
+	return cred, ok
+}
+
+// Middleware 验签通过的请求交给next处理，handler中可以通过CredentialsFromContext获取请求方凭证
+// 验签失败时onError为nil则返回VerifyError.HttpCode()状态码及错误信息
+// next返回非2xx状态码或panic时撤销记录的签名及nonce，SDK对失败请求的重试可以再次通过验签
+func (t *Verifier) Middleware(next http.Handler,
+	onError func(w http.ResponseWriter, r *http.Request, err error)) http.Handler {
+	if onError == nil {
+		onError = writeVerifyError
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, keys, err := t.verify(r)
+		if err != nil {
+			onError(w, r, err)
+			return
+		}
+		rw := &releaseWriter{ResponseWriter: w, release: func() { t.replay.release(keys) }}
+		defer func() {
+			if p := recover(); p != nil {
+				rw.releaseOnce()
+				panic(p)
+			}
+		}()
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), credentialsKey{}, cred)))
+	})
+}
+
+// releaseWriter 在写入非2xx状态码前撤销重放记录，保证客户端收到响应时已经可以重试
+type releaseWriter struct {
+	http.ResponseWriter
+	release     func()
+	released    bool
+	wroteHeader bool
+}
+
+func (t *releaseWriter) releaseOnce() {
+	if !t.released {
+		t.released = true
+		t.release()
+	}
+}
+
+func (t *releaseWriter) WriteHeader(code int) {
+	if !t.wroteHeader {
+		t.wroteHeader = true
+		if code < 200 || code > 299 {
+			t.releaseOnce()
+		}
+	}
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *releaseWriter) Write(b []byte) (int, error) {
+	if !t.wroteHeader {
+		t.WriteHeader(http.StatusOK)
+	}
+	return t.ResponseWriter.Write(b)
+}
+
+func (t *releaseWriter) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func writeVerifyError(w http.ResponseWriter, r *http.Request, err error) {
+	code := http.StatusUnauthorized
+	var ve *VerifyError
+	if errors.As(err, &ve) {
+		code = ve.HttpCode()
+	}
+	http.Error(w, err.Error(), code)
+}
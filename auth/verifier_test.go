@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var verifyCred = &Credentials{AppId: 1, AccessKeyId: "verify-ak", SecretAccessKey: "verify-sk"}
+
+// newSignedRequest 模拟服务端收到的SDK请求，Host不在Header中
+func newSignedRequest(t *testing.T, cred *Credentials, ts int64, body string) *http.Request {
+	req := httptest.NewRequest("POST", "http://xasset.test/xasset/horae/v1/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+	req.Header.Set("Content-Md5", fmt.Sprintf("%x", md5.Sum([]byte(body))))
+	req.Header.Set("Host", "xasset.test")
+	sign, err := Sign(req, cred, &SignOptions{
+		HeadersToSign: DEFAULT_HEADERS_TO_SIGN,
+		Timestamp:     ts,
+		ExpireSeconds: 1800,
+	})
+	if err != nil {
+		t.Fatalf("sign failed.err:%v", err)
+	}
+	req.Header.Del("Host")
+	req.Header.Set("Authorization", sign)
+	return req
+}
+
+func TestVerifierVerify(t *testing.T) {
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	now := time.Now().Unix()
+
+	req := newSignedRequest(t, verifyCred, now, "asset_id=1")
+	cred, err := v.Verify(req)
+	if err != nil || cred.AppId != 1 {
+		t.Fatalf("verify failed.err:%v", err)
+	}
+	if body, _ := ioutil.ReadAll(req.Body); string(body) != "asset_id=1" {
+		t.Errorf("body should be readable after verify.body:%s", body)
+	}
+
+	cases := []struct {
+		name string
+		req  func() *http.Request
+		err  error
+		code int
+	}{
+		{"missing", func() *http.Request {
+			req := newSignedRequest(t, verifyCred, now, "")
+			req.Header.Del("Authorization")
+			return req
+		}, ErrSignMissing, 401},
+		{"format", func() *http.Request {
+			req := newSignedRequest(t, verifyCred, now, "")
+			req.Header.Set("Authorization", "bce-auth-v1/ak/sign")
+			return req
+		}, ErrSignFormat, 401},
+		{"expired", func() *http.Request {
+			return newSignedRequest(t, verifyCred, now-3600, "")
+		}, ErrSignExpired, 401},
+		{"skew", func() *http.Request {
+			return newSignedRequest(t, verifyCred, now+600, "")
+		}, ErrSignClockSkew, 401},
+		{"unknown ak", func() *http.Request {
+			return newSignedRequest(t, &Credentials{AccessKeyId: "other", SecretAccessKey: "sk"}, now, "")
+		}, ErrAccessKeyNotFound, 401},
+		{"wrong sk", func() *http.Request {
+			return newSignedRequest(t, &Credentials{AccessKeyId: "verify-ak", SecretAccessKey: "sk"}, now, "")
+		}, ErrSignMismatch, 401},
+		{"body tampered", func() *http.Request {
+			req := newSignedRequest(t, verifyCred, now, "asset_id=1")
+			req.Body = ioutil.NopCloser(strings.NewReader("asset_id=2"))
+			return req
+		}, ErrContentMd5Mismatch, 400},
+		{"md5 not signed", func() *http.Request {
+			req := newSignedRequest(t, verifyCred, now, "asset_id=1")
+			req.Header.Del("Content-Md5")
+			return req
+		}, ErrContentMd5Missing, 401},
+	}
+	for _, c := range cases {
+		_, err := v.Verify(c.req())
+		var ve *VerifyError
+		if !errors.Is(err, c.err) || !errors.As(err, &ve) || ve.HttpCode() != c.code {
+			t.Errorf("verify error not match.[case:%s] [err:%v]", c.name, err)
+		}
+	}
+}
+
+func TestVerifierClockSkew(t *testing.T) {
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	now := time.Now()
+	// 签名时间在时钟偏差范围内
+	if _, err := v.Verify(newSignedRequest(t, verifyCred, now.Unix()+60, "")); err != nil {
+		t.Errorf("sign within clock skew should pass.err:%v", err)
+	}
+	// 刚过期但在时钟偏差范围内
+	v.now = func() time.Time { return now.Add(1800*time.Second + time.Minute) }
+	if _, err := v.Verify(newSignedRequest(t, verifyCred, now.Unix(), "a=1")); err != nil {
+		t.Errorf("expired within clock skew should pass.err:%v", err)
+	}
+	v.now = func() time.Time { return now.Add(1800*time.Second + 6*time.Minute) }
+	if _, err := v.Verify(newSignedRequest(t, verifyCred, now.Unix(), "a=2")); !errors.Is(err, ErrSignExpired) {
+		t.Errorf("sign should expire.err:%v", err)
+	}
+
+	cfg := NewVerifierConfig()
+	cfg.MaxExpireSeconds = 600
+	v = NewVerifier(NewCredentialsLookup(verifyCred), cfg)
+	if _, err := v.Verify(newSignedRequest(t, verifyCred, now.Unix(), "")); !errors.Is(err, ErrSignFormat) {
+		t.Errorf("expiration over max should be rejected.err:%v", err)
+	}
+}
+
+func TestVerifierMiddleware(t *testing.T) {
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, ok := CredentialsFromContext(r.Context())
+		if !ok || cred.AccessKeyId != verifyCred.AccessKeyId {
+			t.Errorf("credentials should be stored in ctx")
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}), nil)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	send := func(req *http.Request) (*http.Response, string) {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("send request failed.err:%v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+	newReq := func(body string) *http.Request {
+		req, _ := http.NewRequest("POST", srv.URL+"/xasset/horae/v1/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+		req.Header.Set("Content-Md5", fmt.Sprintf("%x", md5.Sum([]byte(body))))
+		// 与SDK一致，签名及实际发送的Host均不带端口
+		req.Host = req.URL.Hostname()
+		req.Header.Set("Host", req.Host)
+		sign, _ := Sign(req, verifyCred, &SignOptions{HeadersToSign: DEFAULT_HEADERS_TO_SIGN})
+		req.Header.Set("Authorization", sign)
+		return req
+	}
+
+	req := newReq("asset_id=1")
+	if resp, body := send(req); resp.StatusCode != 200 || body != "asset_id=1" {
+		t.Fatalf("verified request should pass.code:%d body:%s", resp.StatusCode, body)
+	}
+	// 重放同一个请求
+	replay := newReq("asset_id=1")
+	replay.Header.Set("Authorization", req.Header.Get("Authorization"))
+	if resp, body := send(replay); resp.StatusCode != 401 || !strings.Contains(body, ErrSignReplayed.Error()) {
+		t.Errorf("replayed request should be rejected.code:%d body:%s", resp.StatusCode, body)
+	}
+	req = newReq("asset_id=1")
+	req.Header.Set("Authorization", strings.Replace(req.Header.Get("Authorization"), "verify-ak", "other", 1))
+	if resp, _ := send(req); resp.StatusCode != 401 {
+		t.Errorf("unknown ak should be rejected.code:%d", resp.StatusCode)
+	}
+}
+
+func TestVerifierMiddlewareRelease(t *testing.T) {
+	codes := []int{500, 503, 200}
+	var cnt int
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := codes[cnt]
+		cnt++
+		if code == 500 {
+			panic(http.ErrAbortHandler)
+		}
+		w.WriteHeader(code)
+	}), nil)
+
+	// 处理失败后同一签名及nonce的重试可以通过验签，处理成功后不能再次使用
+	req := newSignedRequest(t, verifyCred, time.Now().Unix(), "nonce=1&asset_id=1")
+	author := req.Header.Get("Authorization")
+	for i, want := range []int{500, 503, 200, 401} {
+		req = newSignedRequest(t, verifyCred, time.Now().Unix(), "nonce=1&asset_id=1")
+		req.Header.Set("Authorization", author)
+		w := httptest.NewRecorder()
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					w.Code = 500
+				}
+			}()
+			handler.ServeHTTP(w, req)
+		}()
+		if w.Code != want {
+			t.Errorf("attempt %d status not match.code:%d want:%d", i, w.Code, want)
+		}
+	}
+}
+
+// countReader 记录读取的字节数
+type countReader struct {
+	n int
+}
+
+func (t *countReader) Read(p []byte) (int, error) {
+	t.n += len(p)
+	return len(p), nil
+}
+
+func TestVerifierBodyAfterSign(t *testing.T) {
+	v := NewVerifier(NewCredentialsLookup(verifyCred), nil)
+	now := time.Now().Unix()
+
+	// 未通过签名校验的请求不读取请求体
+	for _, cred := range []*Credentials{
+		{AccessKeyId: "other", SecretAccessKey: "sk"},
+		{AccessKeyId: "verify-ak", SecretAccessKey: "sk"},
+	} {
+		body := &countReader{}
+		req := newSignedRequest(t, cred, now, "asset_id=1")
+		req.Body = ioutil.NopCloser(body)
+		if _, err := v.Verify(req); err == nil || body.n != 0 {
+			t.Errorf("body should not be read before sign verified.[ak:%s] [read:%d] [err:%v]",
+				cred.AccessKeyId, body.n, err)
+		}
+	}
+
+	body := &countReader{}
+	req := newSignedRequest(t, verifyCred, now, "asset_id=1")
+	req.Body = ioutil.NopCloser(body)
+	if _, err := v.Verify(req); !errors.Is(err, ErrRequestBodyTooLarge) || body.n == 0 {
+		t.Errorf("body should be read after sign verified.[read:%d] [err:%v]", body.n, err)
+	}
+}
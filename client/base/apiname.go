@@ -0,0 +1,82 @@
+package base
+
+// apiNames 接口uri到常量名的映射，用作链路追踪的span名及指标的endpoint标签
+var apiNames = map[string]string{
+	AssetApiCreate:           "AssetApiCreate",
+	AssetApiAlter:            "AssetApiAlter",
+	AssetApiPublish:          "AssetApiPublish",
+	AssetApiQueryAsset:       "AssetApiQueryAsset",
+	AssetApiGrant:            "AssetApiGrant",
+	AssetApiFreeze:           "AssetApiFreeze",
+	AssetApiConsume:          "AssetApiConsume",
+	AssetApiTransfer:         "AssetApiTransfer",
+	AssetApiQueryShard:       "AssetApiQueryShard",
+	AssetApiListShardsByAddr: "AssetApiListShardsByAddr",
+	AssetApiListAssetByAddr:  "AssetApiListAssetByAddr",
+	AssetListShardsByAsset:   "AssetListShardsByAsset",
+	AssetApiGetEvidenceInfo:  "AssetApiGetEvidenceInfo",
+	AssetApiListDiffByAddr:   "AssetApiListDiffByAddr",
+	AssetApiSelectBoxAst:     "AssetApiSelectBoxAst",
+	AssetApiGrantBox:         "AssetApiGrantBox",
+	AssetApiSelectMaterial:   "AssetApiSelectMaterial",
+	AssetApiComposeShard:     "AssetApiComposeShard",
+	AssetApiUpgradeAst:       "AssetApiUpgradeAst",
+	AssetApiUpgradeSds:       "AssetApiUpgradeSds",
+	AssetApiLockShard:        "AssetApiLockShard",
+	AssetApiFreezeShard:      "AssetApiFreezeShard",
+	AssetApiUnfreezeShard:    "AssetApiUnfreezeShard",
+	FileApiGetStoken:         "FileApiGetStoken",
+	ListAssetHistory:         "ListAssetHistory",
+	SceneListShardByAddr:     "SceneListShardByAddr",
+	SceneQueryShard:          "SceneQueryShard",
+	SceneListDiffByAddr:      "SceneListDiffByAddr",
+	SceneListAddr:            "SceneListAddr",
+	SceneHasAstByAddr:        "SceneHasAstByAddr",
+	DidApiRegister:           "DidApiRegister",
+	DidApiBind:               "DidApiBind",
+	DidApiBindByUid:          "DidApiBindByUid",
+	DidApiGetAddrByUid:       "DidApiGetAddrByUid",
+	VilgApiText2Img:          "VilgApiText2Img",
+	VilgApiGetImg:            "VilgApiGetImg",
+	VilgApiBalance:           "VilgApiBalance",
+	StoreApiCreate:           "StoreApiCreate",
+	StoreApiAlter:            "StoreApiAlter",
+	StoreApiQuery:            "StoreApiQuery",
+	StoreApiList:             "StoreApiList",
+	StoreApiCreateAct:        "StoreApiCreateAct",
+	StoreApiAlterAct:         "StoreApiAlterAct",
+	StoreApiRemoveAct:        "StoreApiRemoveAct",
+	StoreApiQueryAct:         "StoreApiQueryAct",
+	StoreApiListAct:          "StoreApiListAct",
+	StoreApiPubAct:           "StoreApiPubAct",
+	StoreApiBindAst:          "StoreApiBindAst",
+	StoreApiAlterAst:         "StoreApiAlterAst",
+	StoreApiCancelAst:        "StoreApiCancelAst",
+	StoreApiCancelAstByActId: "StoreApiCancelAstByActId",
+	StoreApiQueryAst:         "StoreApiQueryAst",
+	StoreApiListAst:          "StoreApiListAst",
+	HubCreateOrder:           "HubCreateOrder",
+	HubConfirmOrder:          "HubConfirmOrder",
+	HubDetailOrder:           "HubDetailOrder",
+	HubEditOrder:             "HubEditOrder",
+	HubListOrder:             "HubListOrder",
+	HubListOrderPage:         "HubListOrderPage",
+	CountOrder:               "CountOrder",
+	SumOrderPrice:            "SumOrderPrice",
+	CheckRefund:              "CheckRefund",
+	CreateRefund:             "CreateRefund",
+	CancelRefund:             "CancelRefund",
+	ConfirmRefund:            "ConfirmRefund",
+	RefuseRefund:             "RefuseRefund",
+	QueryRefund:              "QueryRefund",
+	QueryRefundPage:          "QueryRefundPage",
+	SumRefundPrice:           "SumRefundPrice",
+}
+
+// ApiName 返回接口uri对应的常量名，如AssetApiGrant，未知接口返回uri本身
+func ApiName(uri string) string {
+	if name, ok := apiNames[uri]; ok {
+		return name
+	}
+	return uri
+}
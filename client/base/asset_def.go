@@ -86,15 +86,16 @@ type SelBoxAstResp struct {
 
 type GrantBoxParam struct {
 	Token       string
-	UAccount    *auth.Account
-	CAccount    *auth.Account
+	UAccount    auth.Signer
+	CAccount    auth.Signer
 	RealAssetId int64
 	BoxAssetId  int64
 	UserId      int64
 }
 
 func (t *GrantBoxParam) Valid() error {
-	if t.Token == "" || t.UAccount == nil || t.CAccount == nil || t.RealAssetId < 1 || t.BoxAssetId < 1 {
+	if t.Token == "" || AccountValid(t.UAccount) != nil || AccountValid(t.CAccount) != nil ||
+		t.RealAssetId < 1 || t.BoxAssetId < 1 {
 		return ErrAssetInvalid
 	}
 	return nil
@@ -163,13 +164,13 @@ type ComposeParam struct {
 	Sign     string
 	Token    string
 	AstList  string
-	Account  *auth.Account //composite asset creator
-	UAccount *auth.Account //consume shard owner
+	Account  auth.Signer //composite asset creator
+	UAccount auth.Signer //consume shard owner
 }
 
 func (t *ComposeParam) Valid() error {
 	if t.AssetId < 1 || t.StrgNo <= 0 || t.Nonce < 1 || t.Sign == "" || t.AstList == "" ||
-		t.Account == nil || t.UAccount == nil {
+		AccountValid(t.Account) != nil || AccountValid(t.UAccount) != nil {
 		return ErrAssetInvalid
 	}
 	return nil
@@ -183,7 +184,7 @@ type ComposeResp struct {
 
 /////// Gen Token /////////
 type GetStokenParam struct {
-	Account *auth.Account `json:"account"`
+	Account auth.Signer `json:"account"`
 }
 
 func (t *GetStokenParam) Valid() error {
@@ -207,6 +208,13 @@ type AccessInfo struct {
 	Expiration   string `json:"expiration"`
 }
 
+// String 隐藏临时sk和session token，避免打印到日志
+func (t AccessInfo) String() string {
+	return fmt.Sprintf("{Bucket:%s EndPoint:%s ObjectPath:%s AK:%s SK:%s SessionToken:%s CreateTime:%s Expiration:%s}",
+		t.Bucket, t.EndPoint, t.ObjectPath, t.AK, auth.MaskSecret(t.SK), auth.MaskSecret(t.SessionToken),
+		t.CreateTime, t.Expiration)
+}
+
 type GetStokenResp struct {
 	BaseResp
 	AccessInfo *AccessInfo `json:"accessInfo"`
@@ -220,11 +228,11 @@ type GetStokenResp struct {
 // Property 文件属性。例如图片类型文件，则为图片宽高，格式为 width_height
 // 注意：文件路径和二进制串为二选一
 type UploadFileParam struct {
-	Account  *auth.Account `json:"account"`
-	FileName string        `json:"file_name"`
-	FilePath string        `json:"file_path"`
-	DataByte []byte        `json:"data_byte"`
-	Property string        `json:"property"`
+	Account  auth.Signer `json:"account"`
+	FileName string      `json:"file_name"`
+	FilePath string      `json:"file_path"`
+	DataByte []byte      `json:"data_byte"`
+	Property string      `json:"property"`
 }
 
 func (t *UploadFileParam) Valid() error {
@@ -291,7 +299,7 @@ type CreateAssetParam struct {
 	Price      int64            `json:"price,omitempty"`
 	Amount     int              `json:"amount"`
 	AssetInfo  *CreateAssetInfo `json:"asset_info"`
-	Account    *auth.Account    `json:"account"`
+	Account    auth.Signer      `json:"account"`
 	UserId     int64            `json:"user_id,omitempty"`
 	FileHash   string           `json:"file_hash,omitempty"`
 	ViewType   int              `json:"view_type"`
@@ -365,7 +373,7 @@ type AlterAssetParam struct {
 	Amount    int             `json:"amount,omitempty"`
 	FileHash  string          `json:"file_hash"`
 	AssetInfo *AlterAssetInfo `json:"asset_info"`
-	Account   *auth.Account   `json:"account"`
+	Account   auth.Signer     `json:"account"`
 	ViewType  int             `json:"view_type"`
 }
 
@@ -391,9 +399,9 @@ func (t *AlterAssetParam) Valid() error {
 
 ////////// Publish Asset ////////////
 type PublishAssetParam struct {
-	AssetId    int64         `json:"asset_id"`
-	Account    *auth.Account `json:"account"`
-	IsEvidence int           `json:"is_evidence,omitempty"`
+	AssetId    int64       `json:"asset_id"`
+	Account    auth.Signer `json:"account"`
+	IsEvidence int         `json:"is_evidence,omitempty"`
 }
 
 func (t *PublishAssetParam) Valid() error {
@@ -458,14 +466,14 @@ type QueryAssetMeta struct {
 
 ////////// Grant Asset /////////////
 type GrantAssetParam struct {
-	AssetId    int64         `json:"asset_id"`
-	ShardId    int64         `json:"shard_id"`
-	Price      int64         `json:"price,omitempty"`
-	Account    *auth.Account `json:"account"`
-	Addr       string        `json:"addr"`
-	ToAddr     string        `json:"to_addr"`
-	ToUserId   int64         `json:"to_userid,omitempty"`
-	ShardParam string        `json:"shard_param"`
+	AssetId    int64       `json:"asset_id"`
+	ShardId    int64       `json:"shard_id"`
+	Price      int64       `json:"price,omitempty"`
+	Account    auth.Signer `json:"account"`
+	Addr       string      `json:"addr"`
+	ToAddr     string      `json:"to_addr"`
+	ToUserId   int64       `json:"to_userid,omitempty"`
+	ShardParam string      `json:"shard_param"`
 }
 
 func (p *GrantAssetParam) Valid() error {
@@ -705,13 +713,13 @@ type HoraeAssetObject struct {
 
 ////////// Transfer Asset //////////
 type TransferAssetParam struct {
-	AssetId  int64         `json:"asset_id"`
-	ShardId  int64         `json:"shard_id"`
-	Price    int64         `json:"price,omitempty"`
-	Account  *auth.Account `json:"account"`
-	Addr     string        `json:"addr"`
-	ToAddr   string        `json:"to_addr"`
-	ToUserId int64         `json:"to_userid,omitempty"`
+	AssetId  int64       `json:"asset_id"`
+	ShardId  int64       `json:"shard_id"`
+	Price    int64       `json:"price,omitempty"`
+	Account  auth.Signer `json:"account"`
+	Addr     string      `json:"addr"`
+	ToAddr   string      `json:"to_addr"`
+	ToUserId int64       `json:"to_userid,omitempty"`
 }
 
 func (p *TransferAssetParam) Valid() error {
@@ -741,8 +749,8 @@ func (p *TransferAssetParam) Valid() error {
 
 ////////// Freeze Asset ////////////
 type FreezeAssetParam struct {
-	AssetId int64         `json:"asset_id"`
-	Account *auth.Account `json:"account"`
+	AssetId int64       `json:"asset_id"`
+	Account auth.Signer `json:"account"`
 }
 
 func (t *FreezeAssetParam) Valid() error {
@@ -760,13 +768,13 @@ func (t *FreezeAssetParam) Valid() error {
 
 ////////// Consume Shard ////////////
 type ConsumeShardParam struct {
-	AssetId  int64         `json:"asset_id"`
-	ShardId  int64         `json:"shard_id"`
-	Nonce    int64         `json:"nonce"`
-	UAddr    string        `json:"user_addr"`
-	USign    string        `json:"user_sign"`
-	UPKey    string        `json:"user_pkey"`
-	CAccount *auth.Account `json:"create_account"`
+	AssetId  int64       `json:"asset_id"`
+	ShardId  int64       `json:"shard_id"`
+	Nonce    int64       `json:"nonce"`
+	UAddr    string      `json:"user_addr"`
+	USign    string      `json:"user_sign"`
+	UPKey    string      `json:"user_pkey"`
+	CAccount auth.Signer `json:"create_account"`
 }
 
 func (t *ConsumeShardParam) Valid() error {
@@ -875,7 +883,7 @@ type LockOrFreezeShardParam struct {
 	AssetId int64
 	ShardId int64
 	OpType  int
-	Account *auth.Account
+	Account auth.Signer
 }
 
 func (t *LockOrFreezeShardParam) Valid() error {
@@ -1074,6 +1082,11 @@ type BdBoxRegisterResp struct {
 	IsNew    int    `json:"is_new"`
 }
 
+func (t BdBoxRegisterResp) String() string {
+	return fmt.Sprintf("{RequestId:%s Errno:%d Errmsg:%s Address:%s Mnemonic:%s IsNew:%d}",
+		t.RequestId, t.Errno, t.Errmsg, t.Address, auth.MaskSecret(t.Mnemonic), t.IsNew)
+}
+
 ///////////// Bdbox bind ////////////////////////
 type BdBoxBindParam struct {
 	OpenId   string `json:"open_id"`
@@ -1081,6 +1094,11 @@ type BdBoxBindParam struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+// String 隐藏助记词，避免打印到日志
+func (t BdBoxBindParam) String() string {
+	return fmt.Sprintf("{OpenId:%s AppKey:%s Mnemonic:%s}", t.OpenId, t.AppKey, auth.MaskSecret(t.Mnemonic))
+}
+
 func (t *BdBoxBindParam) Valid() error {
 	if t == nil {
 		return ErrNilPointer
@@ -1103,6 +1121,11 @@ type BindByUnionIdParam struct {
 	Mnemonic string `json:"mnemonic"`
 }
 
+// String 隐藏助记词，避免打印到日志
+func (t BindByUnionIdParam) String() string {
+	return fmt.Sprintf("{UnionId:%s Mnemonic:%s}", t.UnionId, auth.MaskSecret(t.Mnemonic))
+}
+
 func (t *BindByUnionIdParam) Valid() error {
 	if t == nil {
 		return ErrNilPointer
@@ -1,31 +1,38 @@
 package base
 
 import (
+	"context"
 	"crypto/md5"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/xuperchain/xasset-sdk-go/auth"
 	"github.com/xuperchain/xasset-sdk-go/common/config"
 	"github.com/xuperchain/xasset-sdk-go/common/httpcli"
 	"github.com/xuperchain/xasset-sdk-go/common/logs"
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+	"github.com/xuperchain/xasset-sdk-go/common/trace"
 )
 
 // 常用错误
 var (
-	ComErrParamInvalid        = errors.New("param invalid")
-	ComErrAccountSignFailed   = errors.New("account sign failed")
-	ComErrJsonMarFailed       = errors.New("json marhsal failed")
-	ComErrRequsetFailed       = errors.New("send request failed")
-	ComErrRespCodeErr         = errors.New("request resp code error")
-	ComErrUnmarshalBodyFailed = errors.New("json unmarhsal body failed")
-	ComErrServRespErrnoErr    = errors.New("server resp errno error")
-	ComErrGenRequestFailed    = errors.New("generate http request failed")
-	ComErrXassetSignFailed    = errors.New("xasser access sign failed")
-	ComErrConfigErr           = errors.New("client config error")
+	ComErrParamInvalid         = errors.New("param invalid")
+	ComErrAccountSignFailed    = errors.New("account sign failed")
+	ComErrJsonMarFailed        = errors.New("json marhsal failed")
+	ComErrRequsetFailed        = errors.New("send request failed")
+	ComErrRespCodeErr          = errors.New("request resp code error")
+	ComErrUnmarshalBodyFailed  = errors.New("json unmarhsal body failed")
+	ComErrServRespErrnoErr     = errors.New("server resp errno error")
+	ComErrGenRequestFailed     = errors.New("generate http request failed")
+	ComErrXassetSignFailed     = errors.New("xasser access sign failed")
+	ComErrConfigErr            = errors.New("client config error")
+	ComErrGetCredentialsFailed = errors.New("get credentials failed")
+	ComErrCircuitOpen          = errors.New("circuit breaker is open")
 )
 
 // 服务端相应错误码
@@ -119,10 +126,14 @@ func AssetIdValid(assetId int64) error {
 	return nil
 }
 
-func AccountValid(account *auth.Account) error {
+// AccountValid 账户为nil或值为nil指针的Signer均视为无效
+func AccountValid(account auth.Signer) error {
 	if account == nil {
 		return ErrNilPointer
 	}
+	if v := reflect.ValueOf(account); v.Kind() == reflect.Ptr && v.IsNil() {
+		return ErrNilPointer
+	}
 	return nil
 }
 
@@ -263,35 +274,258 @@ type BaseResp struct {
 	Errmsg    string `json:"errmsg"`
 }
 
-// XassetBaseClient
+// XassetBaseClient 初始化后可以在多个goroutine中并发使用
+// 客户端保存配置的副本，单次请求的header、超时等通过RequestOption指定，不修改共享状态
 type XassetBaseClient struct {
 	Cfg         *config.XassetCliConfig
 	Logger      *logs.Logger
-	ExtraHeader map[string]string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	tracer      trace.Tracer
+	breaker     *CircuitBreaker
+	endpoints   *endpointPool
+	metrics     metrics.Collector
+
+	// 保护extraHeader、interceptors、限流规则及Set方法设置的组件
+	lock sync.RWMutex
+	// 每个请求都携带的header，写时复制，通过SetHeader修改
+	extraHeader   map[string]string
+	interceptors  []*Interceptor
+	globalLimiter *limiter
+	uriLimiters   map[string]*limiter
 }
 
 func (t *XassetBaseClient) InitClient(cfg *config.XassetCliConfig, logger logs.LogDriver) error {
 	if cfg == nil || !cfg.IsVaild() {
 		return ComErrParamInvalid
 	}
+	// 保存副本，初始化后修改cfg不影响并发中的请求
+	cfg = cfg.Clone()
 
-	t.Cfg = cfg
 	t.Logger = logs.NewLogger(logger)
-	t.ExtraHeader = make(map[string]string)
+	httpClient, err := newHttpClient(cfg)
+	if err != nil {
+		t.Logger.Warn("create http client failed.[err:%v]", err)
+		return ComErrConfigErr
+	}
+
+	t.Cfg = cfg
+	t.extraHeader = make(map[string]string)
+	t.httpClient = httpClient
+	t.endpoints = newEndpointPool(cfg, httpClient, t.Logger)
+	t.metrics = metrics.NewNoopCollector()
 
 	return nil
 }
 
+// newHttpClient 创建客户端生命周期内复用的http client
+func newHttpClient(cfg *config.XassetCliConfig) (*http.Client, error) {
+	if cfg.HttpClient != nil {
+		return cfg.HttpClient, nil
+	}
+
+	transport := cfg.Transport
+	if transport == nil {
+		tlsConfig := cfg.TlsConfig
+		if tlsConfig == nil {
+			var err error
+			tlsConfig, err = httpcli.NewTlsConfig(cfg.TlsCaFile, cfg.TlsCertFile, cfg.TlsKeyFile,
+				cfg.TlsInsecureSkipVerify)
+			if err != nil {
+				return nil, err
+			}
+		}
+		proxy, err := httpcli.NewProxy(cfg.ProxyUrl, cfg.ProxyFromEnv)
+		if err != nil {
+			return nil, err
+		}
+		transport = httpcli.NewTransport(&httpcli.TransportOptions{
+			ConnTimeoutMs:           cfg.ConnectTimeoutMs,
+			TlsHandshakeTimeoutMs:   cfg.TlsHandshakeTimeoutMs,
+			ResponseHeaderTimeoutMs: cfg.ResponseHeaderTimeoutMs,
+			MaxIdleConns:            cfg.MaxIdleConns,
+			MaxIdleConnsPerHost:     cfg.MaxIdleConnsPerHost,
+			MaxConnsPerHost:         cfg.MaxConnsPerHost,
+			IdleConnTimeoutMs:       cfg.IdleConnTimeoutMs,
+			DisableKeepAlives:       cfg.DisableKeepAlives,
+			TlsConfig:               tlsConfig,
+			Proxy:                   proxy,
+			DialContext:             cfg.DialContext,
+			Resolver:                cfg.Resolver,
+		})
+	}
+
+	return httpcli.NewClient(transport, cfg.RequestTimeoutMs, nil), nil
+}
+
+// Close 停止客户端的后台探测goroutine，客户端不再使用时调用，可重复调用
+// 关闭后仍可以发送请求，但不再探测恢复不可用的服务地址
+func (t *XassetBaseClient) Close() {
+	if t.endpoints != nil {
+		t.endpoints.close()
+	}
+}
+
+func (t *XassetBaseClient) GetHttpClient() *http.Client {
+	return t.httpClient
+}
+
+// SetRetryPolicy 设置失败重试策略，nil表示不重试
+func (t *XassetBaseClient) SetRetryPolicy(policy *RetryPolicy) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.retryPolicy = policy
+}
+
+func (t *XassetBaseClient) getRetryPolicy() *RetryPolicy {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.retryPolicy
+}
+
+// GetConfig 返回客户端使用的配置副本，并发使用时不能修改
 func (t *XassetBaseClient) GetConfig() *config.XassetCliConfig {
 	return t.Cfg
 }
 
-func (t *XassetBaseClient) SetHeader(k, v string) {
-	t.ExtraHeader[k] = v
+// GetCredentials 返回当前的准入凭证，配置了CredentialsProvider时每次都从provider获取
+func (t *XassetBaseClient) GetCredentials() (*auth.Credentials, error) {
+	cfg := t.GetConfig()
+	if cfg.CredentialsProvider == nil {
+		if cfg.Credentials == nil {
+			return nil, ComErrGetCredentialsFailed
+		}
+		return cfg.Credentials, nil
+	}
+
+	cred, err := cfg.CredentialsProvider.Retrieve()
+	if err != nil {
+		t.Logger.Warn("retrieve credentials failed.[err:%v]", err)
+		return nil, err
+	}
+	return cred, nil
+}
+
+// SetHeader 设置每个请求都携带的header，并发安全，只对之后发出的请求生效
+// 只对单次请求生效的header使用WithHeader。与WithHeader相同，不允许设置签名相关header及x-bce-*
+func (t *XassetBaseClient) SetHeader(k, v string) error {
+	if isReservedHeader(k) {
+		t.Logger.Warn("set header failed, header is reserved for signing.[header:%s]", k)
+		return ComErrParamInvalid
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// 写时复制，发送中的请求继续读取旧的map
+	header := make(map[string]string, len(t.extraHeader)+1)
+	for hk, hv := range t.extraHeader {
+		header[hk] = hv
+	}
+	header[k] = v
+	t.extraHeader = header
+	return nil
+}
+
+func (t *XassetBaseClient) getExtraHeader() map[string]string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.extraHeader
 }
 
 func (t *XassetBaseClient) Post(uri, data string) (*RequestRes, error) {
-	reqUrl := fmt.Sprintf("%s%s", t.GetConfig().Endpoint, uri)
+	return t.PostCtx(context.Background(), uri, data)
+}
+
+// PostCtx sends the signed request bound to ctx, so that deadline and cancellation
+// of ctx abort the in-flight http request. Transient failures are retried according
+// to the retry policy of the client. Every attempt waits for the client limits set by
+// SetLimit and SetUriLimit before it is sent, and fails fast with ComErrCircuitOpen
+// while the circuit breaker is open. Options stored in ctx by ContextWithOptions are
+// applied before opts. PostCtx is safe for concurrent use.
+func (t *XassetBaseClient) PostCtx(ctx context.Context, uri, data string,
+	opts ...RequestOption) (*RequestRes, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	reqOpt := newRequestOptions(ctx, opts)
+	if reqOpt.err != nil {
+		t.Logger.Warn("request option invalid.[uri:%s] [err:%v]", uri, reqOpt.err)
+		return nil, ComErrParamInvalid
+	}
+	if reqOpt.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, reqOpt.timeout)
+		defer cancel()
+	}
+
+	policy := t.getRetryPolicy()
+	idempotent := reqOpt.idempotent || IsIdempotentApi(uri)
+	canRetry := policy != nil && idempotent
+	for attempt := 1; ; attempt++ {
+		release, err := t.acquireLimit(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		done, err := t.allowBreaker(uri)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		res, err := t.postFailover(ctx, uri, data, reqOpt.header, idempotent)
+		release()
+		done(res, err)
+		if !canRetry || attempt >= policy.MaxAttempts || !policy.shouldRetry(res, err) {
+			return res, err
+		}
+
+		delay := policy.backoff(attempt)
+		t.Logger.Warn("request will be retried.[uri:%s] [attempt:%d] [delay:%v] [err:%v]",
+			uri, attempt, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// postFailover 选择服务地址发送请求，网络错误时记录地址失败，幂等请求切换到其他地址重新发送
+func (t *XassetBaseClient) postFailover(ctx context.Context, uri, data string,
+	header map[string]string, idempotent bool) (*RequestRes, error) {
+	pool := t.endpoints
+	if pool == nil {
+		return t.post(ctx, t.GetConfig().Endpoint, uri, data, header)
+	}
+
+	tried := make(map[string]bool)
+	ep := pool.pick(tried)
+	for {
+		tried[ep.url] = true
+		res, err := t.post(ctx, ep.url, uri, data, header)
+		if err != ComErrRequsetFailed {
+			if err == nil {
+				pool.markSuccess(ep)
+			}
+			return res, err
+		}
+
+		pool.markFailure(ep)
+		next := pool.pick(tried)
+		if !idempotent || next == nil {
+			return res, err
+		}
+		t.Logger.Warn("request failed, try next endpoint.[uri:%s] [endpoint:%s] [next:%s]",
+			uri, ep.url, next.url)
+		ep = next
+	}
+}
+
+// post 向endpoint发送单次请求，每次调用都重新生成Timestamp、Content-Md5及签名，
+// 签名的Host与实际请求的地址一致，extra为单次请求额外携带的header
+func (t *XassetBaseClient) post(ctx context.Context, endpoint, uri, data string,
+	extra map[string]string) (*RequestRes, error) {
+	reqUrl := fmt.Sprintf("%s%s", endpoint, uri)
 	u, err := url.Parse(reqUrl)
 	if err != nil {
 		t.Logger.Warn("url error.[url:%s] [err:%v]", reqUrl, err)
@@ -304,45 +538,55 @@ func (t *XassetBaseClient) Post(uri, data string) (*RequestRes, error) {
 		"Content-Md5":  fmt.Sprintf("%x", md5.Sum([]byte(data))),
 	}
 
-	req, err := httpcli.GenRequest("POST", reqUrl, header, data)
+	req, err := httpcli.GenRequestWithCtx(ctx, "POST", reqUrl, header, data)
 	if err != nil {
 		t.Logger.Warn("generate request failed.[err:%v]", err)
 		return nil, ComErrGenRequestFailed
 	}
-	sign, err := auth.Sign(req, t.GetConfig().Credentials, t.GetConfig().SignOption)
+	cred, err := t.GetCredentials()
+	if err != nil {
+		return nil, ComErrGetCredentialsFailed
+	}
+	sign, err := auth.Sign(req, cred, t.GetConfig().SignOption)
 	if err != nil {
 		return nil, ComErrXassetSignFailed
 	}
 	req.Header.Set("Authorization", sign)
 
-	for k, v := range t.ExtraHeader {
-		req.Header.Set(k, v)
-	}
+	trace.Inject(ctx, req.Header)
 
-	opts := make(map[string]string)
-	if httpcli.IsHttps(reqUrl) {
-		opts[httpcli.OptTlsSipVerify] = "1"
+	for k, v := range t.getExtraHeader() {
+		req.Header.Set(k, v)
 	}
-	resp, err := httpcli.SendRequest(req, t.GetConfig().ConnectTimeoutMs,
-		t.GetConfig().ReadWriteTimeoutMs, opts)
-	if err != nil {
-		t.Logger.Warn("send http request failed.[url:%s] [err:%v]", reqUrl, err)
-		return nil, ComErrRequsetFailed
+	for k, v := range extra {
+		req.Header.Set(k, v)
 	}
 
-	result := &RequestRes{
-		HttpCode: resp.StatusCode,
-		ReqUrl:   reqUrl,
-		Header:   resp.Header,
-		Body:     string(resp.Body),
-	}
-	return result, nil
+	return t.doIntercept(req, func() (*RequestRes, error) {
+		resp, err := httpcli.DoRequestWithIdleTimeout(t.GetHttpClient(), req,
+			time.Duration(t.GetConfig().IdleReadTimeoutMs)*time.Millisecond)
+		if err != nil {
+			t.Logger.Warn("send http request failed.[url:%s] [err:%v]", reqUrl, err)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, ComErrRequsetFailed
+		}
+
+		result := &RequestRes{
+			HttpCode: resp.StatusCode,
+			ReqUrl:   reqUrl,
+			Header:   resp.Header,
+			Body:     string(resp.Body),
+		}
+		return result, nil
+	})
 }
 
 func (t *XassetBaseClient) GetTarceId(header http.Header) string {
 	var traceId string
 	if header != nil {
-		traceId = header.Get("xasset-trace-id")
+		traceId = header.Get(TraceIdHeader)
 	}
 
 	if traceId == "" {
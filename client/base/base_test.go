@@ -0,0 +1,250 @@
+package base
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+)
+
+func newTestBaseClient(t *testing.T, endpoint string) *XassetBaseClient {
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = endpoint
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	return cli
+}
+
+func TestPostCtxCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.Cfg.ReadWriteTimeoutMs = 5000
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	_, err := cli.PostCtx(ctx, "/xasset/horae/v1/query", "asset_id=1")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("post ctx want deadline exceeded.err:%v", err)
+	}
+	if cost := time.Since(begin); cost > time.Second {
+		t.Fatalf("post ctx not aborted in time.cost:%v", cost)
+	}
+}
+
+func TestPostCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errno":0,"request_id":"1"}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	res, err := cli.PostCtx(context.Background(), "/xasset/horae/v1/query", "asset_id=1")
+	if err != nil {
+		t.Fatalf("post ctx failed.err:%v", err)
+	}
+	if res.HttpCode != 200 || res.Body != `{"errno":0,"request_id":"1"}` {
+		t.Fatalf("post ctx resp unexpected.res:%+v", res)
+	}
+}
+
+func TestPostReuseConn(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	for i := 0; i < 10; i++ {
+		if _, err := cli.Post("/xasset/horae/v1/query", "asset_id=1"); err != nil {
+			t.Fatalf("post failed.err:%v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Fatalf("connection not reused.conns:%d", n)
+	}
+}
+
+type countRoundTripper struct {
+	cnt int32
+}
+
+func (t *countRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.cnt, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestPostCustomTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	rt := &countRoundTripper{}
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cfg.Transport = rt
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	if _, err := cli.Post("/xasset/horae/v1/query", "asset_id=1"); err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+	if atomic.LoadInt32(&rt.cnt) != 1 {
+		t.Fatalf("custom transport unused")
+	}
+
+	hc := &http.Client{Transport: rt}
+	cfg.HttpClient = hc
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	if cli.GetHttpClient() != hc {
+		t.Fatalf("custom http client unused")
+	}
+}
+
+func TestPostTlsVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	if _, err := cli.Post("/xasset/horae/v1/query", "asset_id=1"); err == nil {
+		t.Fatalf("untrusted server certificate should be rejected")
+	}
+
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cfg.TlsInsecureSkipVerify = true
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	if _, err := cli.Post("/xasset/horae/v1/query", "asset_id=1"); err != nil {
+		t.Fatalf("post with explicit skip verify failed.err:%v", err)
+	}
+
+	cfg.TlsInsecureSkipVerify = false
+	cfg.TlsCaFile = "not_exist.pem"
+	if err := cli.InitClient(cfg, &TestLogger{}); err != ComErrConfigErr {
+		t.Fatalf("invalid ca file should fail.err:%v", err)
+	}
+}
+
+type rotateProvider struct {
+	cred atomic.Value
+}
+
+func (t *rotateProvider) Retrieve() (*auth.Credentials, error) {
+	return t.cred.Load().(*auth.Credentials), nil
+}
+
+func TestPostCredentialsProvider(t *testing.T) {
+	provider := &rotateProvider{}
+	provider.cred.Store(&auth.Credentials{AppId: 1, AccessKeyId: "ak1", SecretAccessKey: "sk1"})
+	var wantAk atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("Host", r.Host)
+		cred := provider.cred.Load().(*auth.Credentials)
+		if err := auth.CheckSign(r, cred); err != nil || cred.AccessKeyId != wantAk.Load().(string) {
+			w.WriteHeader(403)
+			return
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cfg.Credentials = nil
+	cfg.CredentialsProvider = provider
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+
+	for _, ak := range []string{"ak1", "ak2"} {
+		provider.cred.Store(&auth.Credentials{AppId: 1, AccessKeyId: ak, SecretAccessKey: "sk-" + ak})
+		wantAk.Store(ak)
+		res, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+		if err != nil || res.HttpCode != 200 {
+			t.Errorf("post with rotated credentials failed.[ak:%s] [err:%v] [res:%+v]", ak, err, res)
+		}
+	}
+}
+
+func TestPostTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == AssetApiQueryAsset {
+			time.Sleep(300 * time.Millisecond)
+		}
+		w.Write([]byte(`{"errno":0,`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`"request_id":"1"}`))
+	}))
+	defer srv.Close()
+
+	cfg := TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cfg.ResponseHeaderTimeoutMs = 100
+	cfg.IdleReadTimeoutMs = 100
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	if cfg.RequestTimeoutMs != config.RequestTimeoutMsDef || cfg.TlsHandshakeTimeoutMs != 0 {
+		t.Errorf("timeout default not match.cfg:%v", cfg)
+	}
+
+	for _, uri := range []string{AssetApiQueryAsset, AssetApiGrant} {
+		start := time.Now()
+		_, err := cli.Post(uri, "")
+		if err != ComErrRequsetFailed || time.Since(start) > 250*time.Millisecond {
+			t.Errorf("post should time out.uri:%s err:%v cost:%v", uri, err, time.Since(start))
+		}
+	}
+
+	cfg = TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cfg.ReadWriteTimeoutMs = 1000
+	cfg.RequestTimeoutMs = 200
+	cli = &XassetBaseClient{}
+	cli.InitClient(cfg, &TestLogger{})
+	if cfg.ResponseHeaderTimeoutMs != 1000 || cfg.IdleReadTimeoutMs != 1000 {
+		t.Errorf("timeout should default to read write timeout.cfg:%v", cfg)
+	}
+	start := time.Now()
+	if _, err := cli.Post(AssetApiGrant, ""); err != ComErrRequsetFailed || time.Since(start) > 280*time.Millisecond {
+		t.Errorf("post should hit request timeout.err:%v cost:%v", err, time.Since(start))
+	}
+}
@@ -0,0 +1,261 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	BreakerConsecutiveFailuresDef = 5
+	BreakerErrorRateDef           = 0.5
+	BreakerMinRequestsDef         = 20
+	BreakerWindowMsDef            = 10000
+	BreakerOpenTimeoutMsDef       = 5000
+	BreakerHalfOpenRequestsDef    = 1
+)
+
+// 熔断器状态
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerConfig 熔断配置，连续失败次数和失败率任一达到阈值即熔断
+type BreakerConfig struct {
+	// 连续失败次数阈值，<=0表示不按连续失败熔断
+	ConsecutiveFailures int
+	// 统计窗口内的失败率阈值，取值(0, 1]，<=0表示不按失败率熔断
+	ErrorRate float64
+	// 统计窗口内请求数达到MinRequests后才按失败率判断
+	MinRequests int
+	// 关闭状态下统计失败率的窗口，窗口结束后清零重新统计
+	WindowMs int
+	// 熔断持续时间，之后进入半开状态放行探测请求
+	OpenTimeoutMs int
+	// 半开状态最多放行的探测请求数，全部成功后关闭熔断，任一失败重新熔断
+	HalfOpenRequests int
+	// 状态变化回调，在状态变化的请求所在goroutine中同步调用，不能阻塞
+	OnStateChange func(from, to BreakerState)
+}
+
+func NewBreakerConfig() *BreakerConfig {
+	return &BreakerConfig{
+		ConsecutiveFailures: BreakerConsecutiveFailuresDef,
+		ErrorRate:           BreakerErrorRateDef,
+		MinRequests:         BreakerMinRequestsDef,
+		WindowMs:            BreakerWindowMsDef,
+		OpenTimeoutMs:       BreakerOpenTimeoutMsDef,
+		HalfOpenRequests:    BreakerHalfOpenRequestsDef,
+	}
+}
+
+// CircuitBreaker 熔断器，服务端不可用时快速失败，避免每个请求都等待连接及读超时
+// 网络错误、超时和5xx响应计为失败，ctx取消、本地错误及熔断拒绝的请求不计入统计
+type CircuitBreaker struct {
+	cfg BreakerConfig
+
+	lock        sync.Mutex
+	state       BreakerState
+	generation  uint64
+	windowStart time.Time
+	openedAt    time.Time
+	requests    int
+	failures    int
+	consecutive int
+	// 半开状态已放行及已成功的探测请求数
+	probes    int
+	probeSucc int
+}
+
+func NewCircuitBreaker(cfg *BreakerConfig) *CircuitBreaker {
+	if cfg == nil {
+		cfg = NewBreakerConfig()
+	}
+	c := *cfg
+	if c.WindowMs <= 0 {
+		c.WindowMs = BreakerWindowMsDef
+	}
+	if c.OpenTimeoutMs <= 0 {
+		c.OpenTimeoutMs = BreakerOpenTimeoutMsDef
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = BreakerHalfOpenRequestsDef
+	}
+	return &CircuitBreaker{
+		cfg:         c,
+		windowStart: time.Now(),
+	}
+}
+
+// State 返回当前状态，熔断超时后返回半开
+func (t *CircuitBreaker) State() BreakerState {
+	t.lock.Lock()
+	state, from := t.currentState(time.Now())
+	t.lock.Unlock()
+	t.notify(from, state)
+	return state
+}
+
+// Allow 判断请求是否放行，放行时返回的done需要在请求结束后以请求结果调用
+func (t *CircuitBreaker) Allow() (func(success, ignore bool), error) {
+	t.lock.Lock()
+	state, from := t.currentState(time.Now())
+	if state == BreakerOpen || (state == BreakerHalfOpen && t.probes >= t.cfg.HalfOpenRequests) {
+		t.lock.Unlock()
+		t.notify(from, state)
+		return nil, ComErrCircuitOpen
+	}
+	if state == BreakerHalfOpen {
+		t.probes++
+	}
+	generation := t.generation
+	t.lock.Unlock()
+	t.notify(from, state)
+
+	var once sync.Once
+	return func(success, ignore bool) {
+		once.Do(func() {
+			t.done(generation, success, ignore)
+		})
+	}, nil
+}
+
+func (t *CircuitBreaker) done(generation uint64, success, ignore bool) {
+	t.lock.Lock()
+	now := time.Now()
+	state, from := t.currentState(now)
+	// 状态已经变化，忽略之前状态下放行的请求
+	if generation != t.generation {
+		t.lock.Unlock()
+		t.notify(from, state)
+		return
+	}
+
+	switch {
+	case ignore:
+		if state == BreakerHalfOpen {
+			t.probes--
+		}
+	case state == BreakerHalfOpen && !success:
+		from, state = state, t.setState(BreakerOpen, now)
+	case state == BreakerHalfOpen:
+		t.probeSucc++
+		if t.probeSucc >= t.cfg.HalfOpenRequests {
+			from, state = state, t.setState(BreakerClosed, now)
+		}
+	case success:
+		t.requests++
+		t.consecutive = 0
+	default:
+		t.requests++
+		t.failures++
+		t.consecutive++
+		if t.shouldTrip() {
+			from, state = state, t.setState(BreakerOpen, now)
+		}
+	}
+	t.lock.Unlock()
+	t.notify(from, state)
+}
+
+func (t *CircuitBreaker) shouldTrip() bool {
+	if t.cfg.ConsecutiveFailures > 0 && t.consecutive >= t.cfg.ConsecutiveFailures {
+		return true
+	}
+	return t.cfg.ErrorRate > 0 && t.requests >= t.cfg.MinRequests && t.requests > 0 &&
+		float64(t.failures)/float64(t.requests) >= t.cfg.ErrorRate
+}
+
+// currentState 处理熔断超时及统计窗口到期，返回当前状态及变化前的状态，需持有锁
+func (t *CircuitBreaker) currentState(now time.Time) (BreakerState, BreakerState) {
+	from := t.state
+	switch t.state {
+	case BreakerClosed:
+		if now.Sub(t.windowStart) >= time.Duration(t.cfg.WindowMs)*time.Millisecond {
+			t.resetCounts(now)
+		}
+	case BreakerOpen:
+		if now.Sub(t.openedAt) >= time.Duration(t.cfg.OpenTimeoutMs)*time.Millisecond {
+			t.setState(BreakerHalfOpen, now)
+		}
+	}
+	return t.state, from
+}
+
+// setState 切换状态并开始新一代统计，需持有锁
+func (t *CircuitBreaker) setState(state BreakerState, now time.Time) BreakerState {
+	t.state = state
+	t.generation++
+	t.resetCounts(now)
+	t.probes, t.probeSucc = 0, 0
+	if state == BreakerOpen {
+		t.openedAt = now
+	}
+	return state
+}
+
+func (t *CircuitBreaker) resetCounts(now time.Time) {
+	t.windowStart = now
+	t.requests, t.failures, t.consecutive = 0, 0, 0
+}
+
+func (t *CircuitBreaker) notify(from, to BreakerState) {
+	if from != to && t.cfg.OnStateChange != nil {
+		t.cfg.OnStateChange(from, to)
+	}
+}
+
+// SetCircuitBreaker 设置熔断器，nil表示不熔断
+// 熔断期间请求直接返回ComErrCircuitOpen，可以通过errors.Is判断
+func (t *XassetBaseClient) SetCircuitBreaker(breaker *CircuitBreaker) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.breaker = breaker
+}
+
+// allowBreaker 经过熔断器判断请求是否放行，返回的done需要以请求结果调用
+func (t *XassetBaseClient) allowBreaker(uri string) (func(res *RequestRes, err error), error) {
+	t.lock.RLock()
+	breaker := t.breaker
+	t.lock.RUnlock()
+	if breaker == nil {
+		return func(res *RequestRes, err error) {}, nil
+	}
+
+	done, err := breaker.Allow()
+	if err != nil {
+		t.Logger.Warn("request rejected by circuit breaker.[uri:%s]", uri)
+		return nil, err
+	}
+	return func(res *RequestRes, err error) {
+		switch {
+		case err == ComErrRequsetFailed || errors.Is(err, context.DeadlineExceeded):
+			// 超时计为失败，服务端无响应时调用方设置的超时往往先于连接超时到达
+			done(false, false)
+		case err != nil:
+			// 调用方取消及签名等本地错误不反映服务端状态
+			done(false, true)
+		case res != nil && res.HttpCode >= 500:
+			done(false, false)
+		default:
+			done(true, false)
+		}
+	}, nil
+}
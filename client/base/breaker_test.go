@@ -0,0 +1,206 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type transitionRecorder struct {
+	lock        sync.Mutex
+	transitions []string
+}
+
+func (t *transitionRecorder) record(from, to BreakerState) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.transitions = append(t.transitions, from.String()+"->"+to.String())
+}
+
+func (t *transitionRecorder) get() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]string{}, t.transitions...)
+}
+
+func allowAndDone(t *testing.T, b *CircuitBreaker, success bool) {
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("breaker should allow.state:%v err:%v", b.State(), err)
+	}
+	done(success, false)
+}
+
+func TestBreakerConsecutiveFailures(t *testing.T) {
+	rec := &transitionRecorder{}
+	b := NewCircuitBreaker(&BreakerConfig{
+		ConsecutiveFailures: 3,
+		OpenTimeoutMs:       50,
+		OnStateChange:       rec.record,
+	})
+
+	allowAndDone(t, b, false)
+	allowAndDone(t, b, false)
+	allowAndDone(t, b, true)
+	allowAndDone(t, b, false)
+	allowAndDone(t, b, false)
+	if b.State() != BreakerClosed {
+		t.Fatalf("success should reset consecutive failures.state:%v", b.State())
+	}
+	allowAndDone(t, b, false)
+	if b.State() != BreakerOpen {
+		t.Fatalf("breaker should be open.state:%v", b.State())
+	}
+	if _, err := b.Allow(); err != ComErrCircuitOpen {
+		t.Errorf("open breaker should reject.err:%v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("breaker should be half-open.state:%v", b.State())
+	}
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("half-open breaker should allow probe.err:%v", err)
+	}
+	if _, err := b.Allow(); err != ComErrCircuitOpen {
+		t.Errorf("half-open breaker should reject more than one probe.err:%v", err)
+	}
+	done(false, false)
+	if b.State() != BreakerOpen {
+		t.Fatalf("failed probe should open breaker.state:%v", b.State())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	allowAndDone(t, b, true)
+	if b.State() != BreakerClosed {
+		t.Fatalf("succ probe should close breaker.state:%v", b.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if got := rec.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("transitions not match.got:%v", got)
+	}
+}
+
+func TestBreakerErrorRate(t *testing.T) {
+	b := NewCircuitBreaker(&BreakerConfig{ErrorRate: 0.5, MinRequests: 4, WindowMs: 1000})
+	allowAndDone(t, b, false)
+	allowAndDone(t, b, true)
+	allowAndDone(t, b, false)
+	allowAndDone(t, b, true)
+	if b.State() != BreakerClosed {
+		t.Fatalf("breaker should only trip on failure.state:%v", b.State())
+	}
+	allowAndDone(t, b, false)
+	if b.State() != BreakerOpen {
+		t.Fatalf("breaker should trip by error rate.state:%v", b.State())
+	}
+
+	// 统计窗口到期后重新统计
+	b = NewCircuitBreaker(&BreakerConfig{ErrorRate: 0.5, MinRequests: 2, WindowMs: 30})
+	allowAndDone(t, b, false)
+	time.Sleep(40 * time.Millisecond)
+	allowAndDone(t, b, true)
+	allowAndDone(t, b, true)
+	allowAndDone(t, b, false)
+	if b.State() != BreakerClosed {
+		t.Errorf("counts should be reset by window.state:%v", b.State())
+	}
+}
+
+func TestBreakerIgnoreAndGeneration(t *testing.T) {
+	b := NewCircuitBreaker(&BreakerConfig{ConsecutiveFailures: 1, OpenTimeoutMs: 30})
+	stale, _ := b.Allow()
+	done, _ := b.Allow()
+	done(true, true)
+	if b.State() != BreakerClosed {
+		t.Fatalf("ignored result should not trip.state:%v", b.State())
+	}
+	allowAndDone(t, b, false)
+	time.Sleep(40 * time.Millisecond)
+
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("half-open should allow probe.err:%v", err)
+	}
+	// 熔断前放行的请求结束不影响半开状态
+	stale(false, false)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("stale result should be ignored.state:%v", b.State())
+	}
+	// 被忽略的探测请求归还名额
+	probe(false, true)
+	probe, err = b.Allow()
+	if err != nil {
+		t.Fatalf("ignored probe should release slot.err:%v", err)
+	}
+	probe(true, false)
+	probe(false, false)
+	if b.State() != BreakerClosed {
+		t.Errorf("done should only take effect once.state:%v", b.State())
+	}
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var lock sync.Mutex
+	code := http.StatusBadGateway
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.WriteHeader(code)
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	rec := &transitionRecorder{}
+	cli := newTestBaseClient(t, srv.URL)
+	breaker := NewCircuitBreaker(&BreakerConfig{
+		ConsecutiveFailures: 2,
+		OpenTimeoutMs:       50,
+		OnStateChange:       rec.record,
+	})
+	cli.SetCircuitBreaker(breaker)
+
+	var resp BaseResp
+	for i := 0; i < 2; i++ {
+		if _, err := cli.Call(context.Background(), AssetApiQueryAsset, "", &resp); !errors.Is(err, ComErrRespCodeErr) {
+			t.Fatalf("call should fail with resp code.err:%v", err)
+		}
+	}
+	res, err := cli.Call(context.Background(), AssetApiQueryAsset, "", &resp)
+	var xerr *XassetError
+	if res != nil || !errors.Is(err, ComErrCircuitOpen) || !errors.As(err, &xerr) || xerr.Uri != AssetApiQueryAsset {
+		t.Fatalf("call should fail fast.res:%v err:%v", res, err)
+	}
+
+	lock.Lock()
+	code = http.StatusOK
+	lock.Unlock()
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cli.Call(context.Background(), AssetApiQueryAsset, "", &resp); err != nil {
+		t.Fatalf("probe call failed.err:%v", err)
+	}
+	if breaker.State() != BreakerClosed {
+		t.Errorf("breaker should be closed.state:%v", breaker.State())
+	}
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if got := rec.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("transitions not match.got:%v", got)
+	}
+
+	// 取消不计为失败
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		cli.Call(ctx, AssetApiQueryAsset, "", &resp)
+	}
+	if breaker.State() != BreakerClosed {
+		t.Errorf("canceled call should not trip.state:%v", breaker.State())
+	}
+}
@@ -0,0 +1,118 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+// Response 接口响应，响应结构体内嵌BaseResp即可满足
+type Response interface {
+	GetBaseResp() *BaseResp
+}
+
+func (t *BaseResp) GetBaseResp() *BaseResp {
+	return t
+}
+
+// Call 是所有接口共用的请求流程：发送请求、检查http状态码、解析响应、检查服务端错误码
+// 失败时返回*XassetError，只要收到了服务端响应就会返回RequestRes
+// 每次调用输出一条带uri、asset_id、shard_id、request_id、trace_id、latency_ms、errno字段的结构化日志，
+// 设置了Tracer时同时记录一个span，设置了指标收集器时记录请求数及耗时
+func (t *XassetBaseClient) Call(ctx context.Context, uri, body string, resp Response,
+	opts ...RequestOption) (res *RequestRes, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	ctx, span := t.startSpan(ctx, uri)
+	defer func() {
+		latency := time.Since(start)
+		info := t.newCallInfo(uri, body, res, resp, err)
+		t.endSpan(span, info, err)
+		t.observeCall(info, latency, err)
+		t.logCall(info, latency, res, err)
+	}()
+
+	res, err = t.PostCtx(ctx, uri, body, opts...)
+	if err != nil {
+		return nil, NewXassetError(err, uri)
+	}
+	if res.HttpCode != 200 {
+		return res, NewRespError(ComErrRespCodeErr, uri, res, nil)
+	}
+
+	err = json.Unmarshal([]byte(res.Body), resp)
+	if err != nil {
+		return res, NewRespError(ComErrUnmarshalBodyFailed, uri, res, nil)
+	}
+	baseResp := resp.GetBaseResp()
+	if baseResp.Errno != XassetErrNoSucc {
+		return res, NewRespError(ComErrServRespErrnoErr, uri, res, baseResp)
+	}
+	return res, nil
+}
+
+// callInfo 一次接口调用的日志、追踪及指标共用信息
+type callInfo struct {
+	uri       string
+	assetId   string
+	shardId   string
+	requestId string
+	traceId   string
+	errno     int
+	httpCode  int
+}
+
+func (t *XassetBaseClient) newCallInfo(uri, body string, res *RequestRes, resp Response,
+	err error) *callInfo {
+	info := &callInfo{uri: uri, errno: XassetErrNoSucc}
+	if form, e := url.ParseQuery(body); e == nil {
+		info.assetId, info.shardId = form.Get("asset_id"), form.Get("shard_id")
+	}
+	if res != nil {
+		info.httpCode = res.HttpCode
+		info.traceId = t.GetTarceId(res.Header)
+	}
+	if resp != nil && resp.GetBaseResp() != nil {
+		info.requestId = resp.GetBaseResp().RequestId
+		info.errno = resp.GetBaseResp().Errno
+	}
+	var xerr *XassetError
+	if err != nil && errors.As(err, &xerr) && xerr.Errno != 0 {
+		info.errno = xerr.Errno
+	}
+	return info
+}
+
+// logCall 输出接口调用日志，成功为Trace级别，失败为Warn级别
+func (t *XassetBaseClient) logCall(info *callInfo, latency time.Duration, res *RequestRes, err error) {
+	if t.Logger == nil {
+		return
+	}
+
+	fields := []logs.Field{
+		logs.String(logs.FieldUri, info.uri),
+		logs.String(logs.FieldAssetId, info.assetId),
+		logs.String(logs.FieldShardId, info.shardId),
+		logs.String(logs.FieldRequestId, info.requestId),
+		logs.String(logs.FieldTraceId, info.traceId),
+		logs.LatencyMs(latency),
+		logs.Int(logs.FieldErrno, info.errno),
+		logs.Int(logs.FieldHttpCode, info.httpCode),
+	}
+	if err != nil {
+		if res != nil && (errors.Is(err, ComErrRespCodeErr) || errors.Is(err, ComErrUnmarshalBodyFailed)) {
+			// 非200及无法解析的响应输出响应内容，便于排查
+			fields = append(fields, logs.String("body", res.Body))
+		}
+		fields = append(fields, logs.Err(err))
+		t.Logger.Log(logs.LevelWarn, "call xasset api failed.", fields...)
+		return
+	}
+	t.Logger.Log(logs.LevelTrace, "call xasset api succ.", fields...)
+}
@@ -0,0 +1,116 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+func TestCall(t *testing.T) {
+	cases := []struct {
+		code    int
+		body    string
+		wantErr error
+	}{
+		{200, `{"request_id":"1","errno":0,"meta":{"asset_id":1}}`, nil},
+		{500, `internal error`, ComErrRespCodeErr},
+		{200, `not json`, ComErrUnmarshalBodyFailed},
+		{200, `{"request_id":"1","errno":10002,"errmsg":"param invalid"}`, ComErrServRespErrnoErr},
+	}
+	for i, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.code)
+			w.Write([]byte(c.body))
+		}))
+		cli := newTestBaseClient(t, srv.URL)
+
+		var resp QueryAssetResp
+		res, err := cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=1", &resp)
+		srv.Close()
+		if !errors.Is(err, c.wantErr) {
+			t.Errorf("case %d call failed.err:%v", i, err)
+			continue
+		}
+		if res == nil || res.HttpCode != c.code || res.Body != c.body {
+			t.Errorf("case %d request res not match.res:%+v", i, res)
+		}
+		if c.wantErr == nil && (resp.RequestId != "1" || resp.Meta == nil || resp.Meta.AssetId != 1) {
+			t.Errorf("case %d resp not match.resp:%+v", i, resp)
+		}
+	}
+}
+
+func TestCallRequestFailed(t *testing.T) {
+	cli := newTestBaseClient(t, "http://127.0.0.1:1")
+	var resp BaseResp
+	res, err := cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=1", &resp)
+	var xe *XassetError
+	if res != nil || !errors.Is(err, ComErrRequsetFailed) || !errors.As(err, &xe) || xe.Uri != AssetApiQueryAsset {
+		t.Errorf("call with request failed.res:%v err:%v", res, err)
+	}
+}
+
+func TestCallLogFields(t *testing.T) {
+	cases := []struct {
+		code   int
+		body   string
+		level  logs.Level
+		fields map[string]interface{}
+	}{
+		{200, `{"request_id":"r1","errno":0}`, logs.LevelTrace,
+			map[string]interface{}{"request_id": "r1", "errno": 0, "http_code": 200}},
+		{502, `bad gateway`, logs.LevelWarn,
+			map[string]interface{}{"request_id": "", "http_code": 502, "body": "bad gateway"}},
+		{200, `{"request_id":"r2","errno":10002,"errmsg":"param invalid"}`, logs.LevelWarn,
+			map[string]interface{}{"request_id": "r2", "errno": 10002, "http_code": 200}},
+	}
+	for i, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(TraceIdHeader, "t1")
+			w.WriteHeader(c.code)
+			w.Write([]byte(c.body))
+		}))
+
+		var levels []logs.Level
+		var got []map[string]interface{}
+		cfg := TestGetXassetConfig()
+		cfg.Endpoint = srv.URL
+		cli := &XassetBaseClient{}
+		err := cli.InitClient(cfg, logs.NewFuncLogger(func(level logs.Level, msg string, fields map[string]interface{}) {
+			if _, ok := fields[logs.FieldUri]; ok {
+				levels, got = append(levels, level), append(got, fields)
+			}
+		}))
+		if err != nil {
+			t.Fatalf("init client failed.err:%v", err)
+		}
+		var resp BaseResp
+		cli.Call(context.Background(), AssetApiGrant, "asset_id=11&shard_id=22&addr=a", &resp)
+		srv.Close()
+
+		if len(got) != 1 || levels[0] != c.level {
+			t.Errorf("case %d call log not match.levels:%v logs:%v", i, levels, got)
+			continue
+		}
+		f := got[0]
+		want := map[string]interface{}{
+			logs.FieldUri: AssetApiGrant, logs.FieldAssetId: "11", logs.FieldShardId: "22",
+			logs.FieldTraceId: "t1",
+		}
+		for k, v := range c.fields {
+			want[k] = v
+		}
+		for k, v := range want {
+			if f[k] != v {
+				t.Errorf("case %d field %s not match.want:%v got:%v", i, k, v, f[k])
+			}
+		}
+		if _, ok := f[logs.FieldLatencyMs].(int64); !ok {
+			t.Errorf("case %d latency_ms not set.fields:%v", i, f)
+		}
+	}
+}
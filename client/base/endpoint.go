@@ -0,0 +1,231 @@
+package base
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+type endpoint struct {
+	url      string
+	priority int
+	weight   int
+
+	// 以下字段由endpointPool.lock保护
+	healthy  bool
+	failures int
+}
+
+// endpointPool 管理多个服务地址，按优先级及权重选择可用地址，
+// 连续网络失败的地址标记为不可用，由后台goroutine探测恢复
+type endpointPool struct {
+	logger        *logs.Logger
+	endpoints     []*endpoint
+	failThreshold int
+	probeInterval time.Duration
+	// 探测地址是否恢复，默认通过客户端的http client发送HEAD请求
+	probe func(ep string) bool
+
+	lock    sync.Mutex
+	probing bool
+	closed  bool
+	// 关闭后探测goroutine退出
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newEndpointPool client为客户端发送请求使用的http client，探测与请求使用相同的代理、建连及域名解析配置
+func newEndpointPool(cfg *config.XassetCliConfig, client *http.Client, logger *logs.Logger) *endpointPool {
+	pool := &endpointPool{
+		logger:        logger,
+		failThreshold: cfg.EndpointFailThreshold,
+		probeInterval: time.Duration(cfg.EndpointProbeIntervalMs) * time.Millisecond,
+		done:          make(chan struct{}),
+	}
+	if pool.failThreshold <= 0 {
+		pool.failThreshold = config.EndpointFailThresholdDef
+	}
+	if pool.probeInterval <= 0 {
+		pool.probeInterval = config.EndpointProbeIntervalMsDef * time.Millisecond
+	}
+	pool.probe = func(ep string) bool {
+		return probeEndpoint(client, ep, pool.probeInterval)
+	}
+
+	for _, ep := range cfg.Endpoints {
+		if ep == nil || ep.Url == "" {
+			continue
+		}
+		weight := ep.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		pool.endpoints = append(pool.endpoints, &endpoint{
+			url:      ep.Url,
+			priority: ep.Priority,
+			weight:   weight,
+			healthy:  true,
+		})
+	}
+	if len(pool.endpoints) == 0 {
+		pool.endpoints = []*endpoint{{url: cfg.Endpoint, weight: 1, healthy: true}}
+	}
+	sort.SliceStable(pool.endpoints, func(i, j int) bool {
+		return pool.endpoints[i].priority < pool.endpoints[j].priority
+	})
+	return pool
+}
+
+// pick 在未尝试过的地址中选择优先级最高的可用地址，同优先级按权重随机
+// 没有可用地址时在未尝试过的地址中选择，所有地址都尝试过时返回nil
+func (t *endpointPool) pick(tried map[string]bool) *endpoint {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if ep := t.pickLocked(tried, true); ep != nil {
+		return ep
+	}
+	return t.pickLocked(tried, false)
+}
+
+func (t *endpointPool) pickLocked(tried map[string]bool, healthyOnly bool) *endpoint {
+	var candidates []*endpoint
+	total := 0
+	for _, ep := range t.endpoints {
+		if tried[ep.url] || (healthyOnly && !ep.healthy) {
+			continue
+		}
+		// endpoints按优先级排序，只在优先级最高的一组中选择
+		if len(candidates) > 0 && ep.priority != candidates[0].priority {
+			break
+		}
+		candidates = append(candidates, ep)
+		total += ep.weight
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	n := rand.Intn(total)
+	for _, ep := range candidates {
+		if n < ep.weight {
+			return ep
+		}
+		n -= ep.weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+func (t *endpointPool) markSuccess(ep *endpoint) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ep.failures = 0
+}
+
+// markFailure 记录网络失败，连续失败达到阈值时标记为不可用并开始探测
+// 只有一个地址时不标记
+func (t *endpointPool) markFailure(ep *endpoint) {
+	if len(t.endpoints) < 2 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	ep.failures++
+	if !ep.healthy || ep.failures < t.failThreshold {
+		return
+	}
+	ep.healthy = false
+	t.logger.Warn("mark endpoint unhealthy.[endpoint:%s] [failures:%d]", ep.url, ep.failures)
+	if !t.probing && !t.closed {
+		t.probing = true
+		go t.probeLoop()
+	}
+}
+
+// probeLoop 定期探测不可用的地址，全部恢复或pool关闭后退出
+func (t *endpointPool) probeLoop() {
+	ticker := time.NewTicker(t.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			t.lock.Lock()
+			t.probing = false
+			t.lock.Unlock()
+			return
+		case <-ticker.C:
+		}
+
+		t.lock.Lock()
+		var unhealthy []*endpoint
+		for _, ep := range t.endpoints {
+			if !ep.healthy {
+				unhealthy = append(unhealthy, ep)
+			}
+		}
+		if len(unhealthy) == 0 {
+			t.probing = false
+			t.lock.Unlock()
+			return
+		}
+		t.lock.Unlock()
+
+		for _, ep := range unhealthy {
+			if !t.probe(ep.url) {
+				continue
+			}
+			t.lock.Lock()
+			ep.healthy, ep.failures = true, 0
+			t.lock.Unlock()
+			t.logger.Info("endpoint restored.[endpoint:%s]", ep.url)
+		}
+	}
+}
+
+// close 停止探测，关闭后不再探测恢复不可用的地址，可重复调用
+func (t *endpointPool) close() {
+	t.closeOnce.Do(func() {
+		t.lock.Lock()
+		t.closed = true
+		t.lock.Unlock()
+		close(t.done)
+	})
+}
+
+// healthy 返回地址当前是否可用
+func (t *endpointPool) healthy(epUrl string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, ep := range t.endpoints {
+		if ep.url == epUrl {
+			return ep.healthy
+		}
+	}
+	return false
+}
+
+// probeEndpoint 发送HEAD请求探测地址，收到任意http响应即认为地址可用，超时不超过探测间隔
+func probeEndpoint(client *http.Client, ep string, timeout time.Duration) bool {
+	if client == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "HEAD", ep, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
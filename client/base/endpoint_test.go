@@ -0,0 +1,203 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+	"github.com/xuperchain/xasset-sdk-go/common/logs"
+)
+
+func TestEndpointPick(t *testing.T) {
+	cfg := TestGetXassetConfig()
+	cfg.Endpoints = []*config.EndpointConfig{
+		{Url: "http://c", Priority: 1},
+		{Url: "http://a", Weight: 3},
+		{Url: "http://b", Weight: 1},
+	}
+	pool := newEndpointPool(cfg, nil, logs.NewLogger(&TestLogger{}))
+
+	cnt := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		cnt[pool.pick(nil).url]++
+	}
+	if cnt["http://c"] != 0 || cnt["http://a"] < 2600 || cnt["http://a"] > 3400 {
+		t.Errorf("weighted pick not match.cnt:%v", cnt)
+	}
+
+	tried := map[string]bool{"http://a": true}
+	if ep := pool.pick(tried); ep.url != "http://b" {
+		t.Errorf("pick should skip tried endpoint.ep:%s", ep.url)
+	}
+	tried["http://b"] = true
+	if ep := pool.pick(tried); ep.url != "http://c" {
+		t.Errorf("pick should fall to lower priority.ep:%s", ep.url)
+	}
+	tried["http://c"] = true
+	if ep := pool.pick(tried); ep != nil {
+		t.Errorf("pick should return nil when all tried.ep:%s", ep.url)
+	}
+
+	for _, ep := range pool.endpoints {
+		ep.healthy = ep.url == "http://c"
+	}
+	if ep := pool.pick(nil); ep.url != "http://c" {
+		t.Errorf("pick should skip unhealthy endpoint.ep:%s", ep.url)
+	}
+	for _, ep := range pool.endpoints {
+		ep.healthy = false
+	}
+	if ep := pool.pick(nil); ep.priority != 0 {
+		t.Errorf("pick should use unhealthy endpoints when none healthy.ep:%s", ep.url)
+	}
+}
+
+func TestEndpointFailover(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		// 签名的Host为实际请求的地址
+		r.Header.Set("Host", r.Host)
+		if !strings.HasPrefix(r.Host, "localhost") || auth.CheckSign(r, TestGetXassetConfig().Credentials) != nil {
+			w.WriteHeader(403)
+			return
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+	live := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed.err:%v", err)
+	}
+	dead := "http://" + ln.Addr().String()
+	ln.Close()
+
+	cfg := TestGetXassetConfig()
+	cfg.Endpoints = []*config.EndpointConfig{
+		{Url: dead, Priority: 0},
+		{Url: live, Priority: 1},
+	}
+	cfg.EndpointProbeIntervalMs = 20
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	var restored atomic.Value
+	restored.Store(false)
+	cli.endpoints.probe = func(ep string) bool {
+		return ep == dead && restored.Load().(bool)
+	}
+
+	var resp BaseResp
+	if _, err := cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=1", &resp); err != nil {
+		t.Fatalf("idempotent call should fail over.err:%v", err)
+	}
+	if cli.endpoints.healthy(dead) || hits != 1 {
+		t.Fatalf("dead endpoint should be unhealthy.hits:%d", hits)
+	}
+	if _, err := cli.Call(context.Background(), AssetApiGrant, "asset_id=1", &resp); err != nil || hits != 2 {
+		t.Fatalf("call should use healthy endpoint.err:%v hits:%d", err, hits)
+	}
+
+	restored.Store(true)
+	deadline := time.Now().Add(time.Second)
+	for !cli.endpoints.healthy(dead) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !cli.endpoints.healthy(dead) {
+		t.Fatalf("dead endpoint should be restored by probe")
+	}
+
+	// 非幂等请求不切换地址重发
+	_, err = cli.Call(context.Background(), AssetApiGrant, "asset_id=1", &resp)
+	if !errors.Is(err, ComErrRequsetFailed) || hits != 2 || cli.endpoints.healthy(dead) {
+		t.Errorf("non idempotent call should not fail over.err:%v hits:%d", err, hits)
+	}
+}
+
+func TestEndpointProbeClose(t *testing.T) {
+	cfg := TestGetXassetConfig()
+	cfg.Endpoints = []*config.EndpointConfig{{Url: "http://a"}, {Url: "http://b"}}
+	cfg.EndpointProbeIntervalMs = 10
+	cli := &XassetBaseClient{}
+	if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+		t.Fatalf("init client failed.err:%v", err)
+	}
+	cli.endpoints.probe = func(ep string) bool { return false }
+	probing := func() bool {
+		cli.endpoints.lock.Lock()
+		defer cli.endpoints.lock.Unlock()
+		return cli.endpoints.probing
+	}
+
+	cli.endpoints.markFailure(cli.endpoints.endpoints[0])
+	if !probing() {
+		t.Fatalf("probe loop should start")
+	}
+	cli.Close()
+	deadline := time.Now().Add(time.Second)
+	for probing() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if probing() {
+		t.Fatalf("probe loop should stop after close")
+	}
+
+	// 关闭后不再启动探测，重复关闭不panic
+	cli.endpoints.markFailure(cli.endpoints.endpoints[1])
+	cli.Close()
+	if probing() {
+		t.Errorf("probe loop should not start after close")
+	}
+}
+
+func TestEndpointProbeProxy(t *testing.T) {
+	var proxyHost atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyHost.Store(r.Host)
+	}))
+	defer proxy.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	newCli := func(setup func(cfg *config.XassetCliConfig)) *XassetBaseClient {
+		cfg := TestGetXassetConfig()
+		cfg.Endpoints = []*config.EndpointConfig{{Url: "http://xasset-a.invalid"}, {Url: "http://xasset-b.invalid"}}
+		cfg.EndpointProbeIntervalMs = 1000
+		setup(cfg)
+		cli := &XassetBaseClient{}
+		if err := cli.InitClient(cfg, &TestLogger{}); err != nil {
+			t.Fatalf("init client failed.err:%v", err)
+		}
+		return cli
+	}
+
+	// 只能通过代理访问的地址
+	cli := newCli(func(cfg *config.XassetCliConfig) { cfg.ProxyUrl = proxy.URL })
+	if !cli.endpoints.probe("http://xasset-a.invalid") || proxyHost.Load() != "xasset-a.invalid" {
+		t.Errorf("probe should go through proxy.host:%v", proxyHost.Load())
+	}
+	// 只能通过自定义建连方法访问的地址
+	cli = newCli(func(cfg *config.XassetCliConfig) {
+		cfg.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		}
+	})
+	if !cli.endpoints.probe("http://xasset-b.invalid") {
+		t.Errorf("probe should use custom dial context")
+	}
+	cli = newCli(func(cfg *config.XassetCliConfig) {})
+	if cli.endpoints.probe("http://xasset-a.invalid") {
+		t.Errorf("unreachable endpoint should not be restored")
+	}
+}
@@ -0,0 +1,135 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrnoCategory 服务端错误码分类，便于告警和重试按类别处理
+type ErrnoCategory int
+
+const (
+	// 未登记的错误码
+	ErrnoCategoryUnknown ErrnoCategory = iota
+	// 服务端临时故障，可以重试
+	ErrnoCategoryRetryable
+	// 调用方参数或业务状态错误，重试无意义
+	ErrnoCategoryCaller
+	// 鉴权失败，需要检查ak/sk或应用权限
+	ErrnoCategoryAuth
+)
+
+func (c ErrnoCategory) String() string {
+	switch c {
+	case ErrnoCategoryRetryable:
+		return "retryable"
+	case ErrnoCategoryCaller:
+		return "caller_error"
+	case ErrnoCategoryAuth:
+		return "auth_failure"
+	}
+	return "unknown"
+}
+
+// 返回错误码的服务
+const (
+	ServiceCommon   = "common"
+	ServiceHorae    = "horae"
+	ServiceDamocles = "damocles"
+	ServiceStore    = "store"
+	ServiceTrade    = "trade"
+	ServiceDid      = "did"
+	ServiceVilg     = "vilg"
+)
+
+// ErrnoInfo 错误码登记信息
+type ErrnoInfo struct {
+	Errno    int
+	Service  string
+	Desc     string
+	Category ErrnoCategory
+}
+
+func (t ErrnoInfo) String() string {
+	return fmt.Sprintf("[errno:%d] [service:%s] [desc:%s] [category:%s]",
+		t.Errno, t.Service, t.Desc, t.Category)
+}
+
+var (
+	errnoLock     sync.RWMutex
+	errnoRegistry = map[int]ErrnoInfo{}
+)
+
+// 服务端错误码表（horae、damocles、store、trade、did、vilg）尚未由各服务公布，
+// 为避免按猜测的错误码分类导致告警和重试误判，SDK暂不内置，待错误码表公布后补充常量及分类。
+// 在此之前未登记的错误码分类为ErrnoCategoryUnknown，调用方可以通过RegisterErrno登记已知错误码
+func init() {
+	errnoRegistry[XassetErrNoSucc] = ErrnoInfo{XassetErrNoSucc, ServiceCommon, "成功", ErrnoCategoryUnknown}
+}
+
+// RegisterErrno 登记或覆盖错误码，SDK不内置服务端错误码，调用方按服务端公布的错误码表登记
+func RegisterErrno(info ErrnoInfo) {
+	errnoLock.Lock()
+	defer errnoLock.Unlock()
+	errnoRegistry[info.Errno] = info
+}
+
+// LookupErrno 查询错误码登记信息，未登记时分类为ErrnoCategoryUnknown
+func LookupErrno(errno int) (ErrnoInfo, bool) {
+	errnoLock.RLock()
+	defer errnoLock.RUnlock()
+	info, ok := errnoRegistry[errno]
+	if !ok {
+		return ErrnoInfo{Errno: errno, Desc: "unknown errno", Category: ErrnoCategoryUnknown}, false
+	}
+	return info, true
+}
+
+// RetryableErrnos 返回所有登记为可重试的错误码，可用于设置RetryPolicy.RetryErrnos
+func RetryableErrnos() []int {
+	errnoLock.RLock()
+	defer errnoLock.RUnlock()
+	var list []int
+	for errno, info := range errnoRegistry {
+		if info.Category == ErrnoCategoryRetryable {
+			list = append(list, errno)
+		}
+	}
+	return list
+}
+
+// ErrCategory 返回接口错误的分类
+// 有服务端错误码时按错误码分类，否则按网络错误和http状态码分类
+func ErrCategory(err error) ErrnoCategory {
+	var xe *XassetError
+	if !errors.As(err, &xe) {
+		return ErrnoCategoryUnknown
+	}
+	return xe.Category()
+}
+
+// ErrnoInfo 返回服务端错误码的登记信息，没有服务端错误码时返回false
+func (e *XassetError) ErrnoInfo() (ErrnoInfo, bool) {
+	if e.Errno == XassetErrNoSucc {
+		return ErrnoInfo{}, false
+	}
+	return LookupErrno(e.Errno)
+}
+
+func (e *XassetError) Category() ErrnoCategory {
+	if info, ok := e.ErrnoInfo(); ok || e.Errno != XassetErrNoSucc {
+		return info.Category
+	}
+
+	if errors.Is(e.Err, ComErrRequsetFailed) {
+		return ErrnoCategoryRetryable
+	}
+	switch e.HttpCode {
+	case 429, 502, 503, 504:
+		return ErrnoCategoryRetryable
+	case 401, 403:
+		return ErrnoCategoryAuth
+	}
+	return ErrnoCategoryUnknown
+}
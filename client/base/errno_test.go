@@ -0,0 +1,77 @@
+package base
+
+import (
+	"fmt"
+	"testing"
+)
+
+// 测试使用的错误码，SDK不内置服务端错误码
+const (
+	testErrnoBusy   = 99001
+	testErrnoCaller = 99002
+	testErrnoAuth   = 99003
+)
+
+func init() {
+	RegisterErrno(ErrnoInfo{Errno: testErrnoBusy, Service: ServiceCommon, Desc: "busy", Category: ErrnoCategoryRetryable})
+	RegisterErrno(ErrnoInfo{Errno: testErrnoCaller, Service: ServiceHorae, Desc: "caller", Category: ErrnoCategoryCaller})
+	RegisterErrno(ErrnoInfo{Errno: testErrnoAuth, Service: ServiceCommon, Desc: "auth", Category: ErrnoCategoryAuth})
+}
+
+func TestLookupErrno(t *testing.T) {
+	info, ok := LookupErrno(testErrnoAuth)
+	if !ok || info.Category != ErrnoCategoryAuth || info.Service != ServiceCommon {
+		t.Errorf("lookup errno failed.info:%v", info)
+	}
+	info, ok = LookupErrno(99999)
+	if ok || info.Category != ErrnoCategoryUnknown {
+		t.Errorf("lookup unknown errno failed.info:%v", info)
+	}
+
+	RegisterErrno(ErrnoInfo{Errno: 99999, Service: ServiceVilg, Desc: "test", Category: ErrnoCategoryRetryable})
+	info, ok = LookupErrno(99999)
+	if !ok || info.Category != ErrnoCategoryRetryable {
+		t.Errorf("lookup registered errno failed.info:%v", info)
+	}
+}
+
+func TestErrCategory(t *testing.T) {
+	cases := []struct {
+		err  error
+		want ErrnoCategory
+	}{
+		{NewRespError(ComErrServRespErrnoErr, AssetApiGrant, nil, &BaseResp{Errno: testErrnoBusy}), ErrnoCategoryRetryable},
+		{NewRespError(ComErrServRespErrnoErr, AssetApiGrant, nil, &BaseResp{Errno: testErrnoCaller}), ErrnoCategoryCaller},
+		{NewRespError(ComErrServRespErrnoErr, AssetApiGrant, nil, &BaseResp{Errno: testErrnoAuth}), ErrnoCategoryAuth},
+		// 未登记的错误码不按http状态码分类
+		{NewRespError(ComErrServRespErrnoErr, AssetApiGrant, nil, &BaseResp{Errno: 88888}), ErrnoCategoryUnknown},
+		{NewRespError(ComErrRespCodeErr, AssetApiGrant, &RequestRes{HttpCode: 503}, nil), ErrnoCategoryRetryable},
+		{NewRespError(ComErrRespCodeErr, AssetApiGrant, &RequestRes{HttpCode: 403}, nil), ErrnoCategoryAuth},
+		{NewXassetError(ComErrRequsetFailed, AssetApiGrant), ErrnoCategoryRetryable},
+		{fmt.Errorf("wrap: %w", NewXassetError(ComErrRequsetFailed, AssetApiGrant)), ErrnoCategoryRetryable},
+		{ComErrRequsetFailed, ErrnoCategoryUnknown},
+	}
+	for i, c := range cases {
+		if got := ErrCategory(c.err); got != c.want {
+			t.Errorf("case %d category not match.got:%s want:%s", i, got, c.want)
+		}
+	}
+}
+
+func TestRetryPolicyRetryableErrnos(t *testing.T) {
+	// 默认只按网络错误和http状态码重试
+	policy := NewRetryPolicy()
+	body := fmt.Sprintf(`{"errno":%d}`, testErrnoBusy)
+	if policy.shouldRetry(&RequestRes{HttpCode: 200, Body: body}, nil) {
+		t.Errorf("errno should not be retried by default")
+	}
+
+	policy.RetryErrnos = RetryableErrnos()
+	if !policy.shouldRetry(&RequestRes{HttpCode: 200, Body: body}, nil) {
+		t.Errorf("retryable errno should be retried")
+	}
+	body = fmt.Sprintf(`{"errno":%d}`, testErrnoCaller)
+	if policy.shouldRetry(&RequestRes{HttpCode: 200, Body: body}, nil) {
+		t.Errorf("caller errno should not be retried")
+	}
+}
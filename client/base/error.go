@@ -0,0 +1,67 @@
+package base
+
+import (
+	"fmt"
+)
+
+// 服务端返回的链路追踪id header
+const TraceIdHeader = "xasset-trace-id"
+
+// XassetError 接口调用失败时返回的错误，携带请求上下文
+// 可通过errors.Is(err, ComErrXxx)判断具体的失败类型
+type XassetError struct {
+	// 失败类型，ComErrXxx或者底层错误
+	Err error
+	// http状态码，请求未发出时为0
+	HttpCode int
+	// 服务端错误码及错误信息
+	Errno  int
+	Errmsg string
+	// 服务端请求id
+	RequestId string
+	// 服务端链路追踪id，取自xasset-trace-id header
+	TraceId string
+	// 请求的接口
+	Uri string
+}
+
+func (e *XassetError) Error() string {
+	return fmt.Sprintf("%v.[uri:%s] [http_code:%d] [errno:%d] [errmsg:%s] [request_id:%s] [trace_id:%s]",
+		e.Err, e.Uri, e.HttpCode, e.Errno, e.Errmsg, e.RequestId, e.TraceId)
+}
+
+func (e *XassetError) Unwrap() error {
+	return e.Err
+}
+
+// NewXassetError 构造未拿到服务端响应时的错误
+// 如果err本身已经是*XassetError则原样返回
+func NewXassetError(err error, uri string) error {
+	if xe, ok := err.(*XassetError); ok {
+		return xe
+	}
+	return &XassetError{
+		Err: err,
+		Uri: uri,
+	}
+}
+
+// NewRespError 根据服务端响应构造错误，resp为空时只填充http相关信息
+func NewRespError(err error, uri string, res *RequestRes, resp *BaseResp) error {
+	xe := &XassetError{
+		Err: err,
+		Uri: uri,
+	}
+	if res != nil {
+		xe.HttpCode = res.HttpCode
+		if res.Header != nil {
+			xe.TraceId = res.Header.Get(TraceIdHeader)
+		}
+	}
+	if resp != nil {
+		xe.Errno = resp.Errno
+		xe.Errmsg = resp.Errmsg
+		xe.RequestId = resp.RequestId
+	}
+	return xe
+}
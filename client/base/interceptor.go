@@ -0,0 +1,75 @@
+package base
+
+import (
+	"net/http"
+)
+
+// Interceptor 请求拦截器，每次发送请求（包括重试）都会经过拦截器链
+// 两个钩子都是可选的
+type Interceptor struct {
+	// 拦截器名称，用于日志
+	Name string
+	// BeforeSend 在请求签名之后、发送之前按添加顺序调用，可以添加header，
+	// 修改已参与签名的header会导致服务端验签失败。
+	// 返回非nil的RequestRes或error时不再发送请求，直接以该结果作为响应（如返回缓存）
+	BeforeSend func(req *http.Request) (*RequestRes, error)
+	// AfterReceive 在收到响应后按添加顺序的逆序调用，可以替换响应或错误
+	// 请求被短路时只调用已经执行过BeforeSend的拦截器
+	AfterReceive func(req *http.Request, res *RequestRes, err error) (*RequestRes, error)
+}
+
+// AddInterceptor 在拦截器链末尾添加拦截器，并发安全
+func (t *XassetBaseClient) AddInterceptor(interceptors ...*Interceptor) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// 写时复制，发送中的请求继续使用旧的拦截器链
+	chain := make([]*Interceptor, 0, len(t.interceptors)+len(interceptors))
+	chain = append(chain, t.interceptors...)
+	for _, it := range interceptors {
+		if it != nil {
+			chain = append(chain, it)
+		}
+	}
+	t.interceptors = chain
+}
+
+func (t *XassetBaseClient) getInterceptors() []*Interceptor {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.interceptors
+}
+
+// doIntercept 经过拦截器链发送请求，send为实际发送请求的函数
+func (t *XassetBaseClient) doIntercept(req *http.Request,
+	send func() (*RequestRes, error)) (*RequestRes, error) {
+	chain := t.getInterceptors()
+
+	var res *RequestRes
+	var err error
+	shortCircuit := false
+	called := 0
+	for _, it := range chain {
+		called++
+		if it.BeforeSend == nil {
+			continue
+		}
+		res, err = it.BeforeSend(req)
+		if res != nil || err != nil {
+			t.Logger.Trace("request short circuited by interceptor.[name:%s] [url:%s] [err:%v]",
+				it.Name, req.URL.String(), err)
+			shortCircuit = true
+			break
+		}
+	}
+	if !shortCircuit {
+		res, err = send()
+	}
+
+	for i := called - 1; i >= 0; i-- {
+		if chain[i].AfterReceive == nil {
+			continue
+		}
+		res, err = chain[i].AfterReceive(req, res, err)
+	}
+	return res, err
+}
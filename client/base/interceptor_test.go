@@ -0,0 +1,131 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "tenant-a" {
+			w.WriteHeader(400)
+			return
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+	cli := newTestBaseClient(t, srv.URL)
+
+	var trace []string
+	newInterceptor := func(name string) *Interceptor {
+		return &Interceptor{
+			Name: name,
+			BeforeSend: func(req *http.Request) (*RequestRes, error) {
+				trace = append(trace, "before_"+name)
+				return nil, nil
+			},
+			AfterReceive: func(req *http.Request, res *RequestRes, err error) (*RequestRes, error) {
+				trace = append(trace, "after_"+name)
+				return res, err
+			},
+		}
+	}
+	tenant := &Interceptor{
+		Name: "tenant",
+		BeforeSend: func(req *http.Request) (*RequestRes, error) {
+			req.Header.Set("X-Tenant", "tenant-a")
+			return nil, nil
+		},
+	}
+	cli.AddInterceptor(newInterceptor("a"), tenant, newInterceptor("b"))
+
+	res, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+	if err != nil || res.HttpCode != 200 {
+		t.Fatalf("post with interceptor failed.err:%v res:%+v", err, res)
+	}
+	want := "[before_a before_b after_b after_a]"
+	if fmt.Sprint(trace) != want {
+		t.Errorf("interceptor order not match.trace:%v", trace)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+	cli := newTestBaseClient(t, srv.URL)
+
+	// 简单的响应缓存
+	var lock sync.Mutex
+	cache := make(map[string]*RequestRes)
+	afterCalled := 0
+	cli.AddInterceptor(&Interceptor{
+		Name: "cache",
+		BeforeSend: func(req *http.Request) (*RequestRes, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			return cache[req.URL.Path], nil
+		},
+		AfterReceive: func(req *http.Request, res *RequestRes, err error) (*RequestRes, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			afterCalled++
+			if err == nil {
+				cache[req.URL.Path] = res
+			}
+			return res, err
+		},
+	}, &Interceptor{
+		Name: "never",
+		BeforeSend: func(req *http.Request) (*RequestRes, error) {
+			return nil, errors.New("should be short circuited")
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+		if i == 0 && err == nil {
+			t.Fatalf("first request should be failed by interceptor")
+		}
+		if i == 0 {
+			cache[AssetApiQueryAsset] = &RequestRes{HttpCode: 200, Body: `{"errno":0}`}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("post with cache failed.err:%v", err)
+		}
+	}
+	if hits != 0 || afterCalled != 3 {
+		t.Errorf("short circuit failed.hits:%d after_called:%d", hits, afterCalled)
+	}
+}
+
+func TestSetHeaderConcurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+	cli := newTestBaseClient(t, srv.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			cli.SetHeader(fmt.Sprintf("X-Header-%d", i), "v")
+		}(i)
+		go func() {
+			defer wg.Done()
+			cli.Post(AssetApiQueryAsset, "asset_id=1")
+		}()
+	}
+	wg.Wait()
+}
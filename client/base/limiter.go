@@ -0,0 +1,180 @@
+package base
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LimitRule 客户端限流规则，零值表示不限制
+type LimitRule struct {
+	// 令牌桶每秒生成的令牌数，每次请求（包括重试）消耗一个令牌，<=0表示不限速
+	Qps float64
+	// 令牌桶容量，即允许的突发请求数，<=0时为1
+	Burst int
+	// 最大并发请求数，<=0表示不限制
+	MaxInFlight int
+}
+
+// SetLimit 设置所有接口共用的限流规则，nil表示不限制
+// 超出限制的请求阻塞等待，ctx结束时返回ctx的错误
+func (t *XassetBaseClient) SetLimit(rule *LimitRule) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.globalLimiter = newLimiter(rule)
+}
+
+// SetUriLimit 设置单个接口的限流规则，与全局规则同时生效，nil表示不限制
+func (t *XassetBaseClient) SetUriLimit(uri string, rule *LimitRule) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	// 写时复制，等待中的请求继续使用旧的规则
+	limiters := make(map[string]*limiter, len(t.uriLimiters)+1)
+	for k, v := range t.uriLimiters {
+		limiters[k] = v
+	}
+	if l := newLimiter(rule); l != nil {
+		limiters[uri] = l
+	} else {
+		delete(limiters, uri)
+	}
+	t.uriLimiters = limiters
+}
+
+// acquireLimit 等待全局及接口的限流规则放行，返回的release需要在请求结束后调用
+func (t *XassetBaseClient) acquireLimit(ctx context.Context, uri string) (func(), error) {
+	t.lock.RLock()
+	limiters := []*limiter{t.globalLimiter, t.uriLimiters[uri]}
+	t.lock.RUnlock()
+
+	acquired := make([]*limiter, 0, len(limiters))
+	release := func() {
+		for _, l := range acquired {
+			l.release()
+		}
+	}
+	for _, l := range limiters {
+		if l == nil {
+			continue
+		}
+		if err := l.acquire(ctx); err != nil {
+			release()
+			t.Logger.Warn("wait for client limit failed.[uri:%s] [err:%v]", uri, err)
+			return nil, err
+		}
+		acquired = append(acquired, l)
+	}
+	return release, nil
+}
+
+type limiter struct {
+	bucket *tokenBucket
+	sem    chan struct{}
+}
+
+func newLimiter(rule *LimitRule) *limiter {
+	if rule == nil || (rule.Qps <= 0 && rule.MaxInFlight <= 0) {
+		return nil
+	}
+
+	l := &limiter{}
+	if rule.Qps > 0 {
+		l.bucket = newTokenBucket(rule.Qps, rule.Burst)
+	}
+	if rule.MaxInFlight > 0 {
+		l.sem = make(chan struct{}, rule.MaxInFlight)
+	}
+	return l
+}
+
+// acquire 先占用并发名额再等待令牌，避免持有令牌时长时间等待并发名额
+func (t *limiter) acquire(ctx context.Context) error {
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if t.bucket != nil {
+		if err := t.bucket.wait(ctx); err != nil {
+			t.release()
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *limiter) release() {
+	if t.sem != nil {
+		<-t.sem
+	}
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(qps float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   qps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve 预占一个令牌，返回需要等待的时间，令牌不足时令牌数为负表示已被预占
+func (t *tokenBucket) reserve() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	t.tokens += now.Sub(t.last).Seconds() * t.rate
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+	t.last = now
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+// cancel 归还预占的令牌
+func (t *tokenBucket) cancel() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tokens++
+}
+
+func (t *tokenBucket) wait(ctx context.Context) error {
+	delay := t.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	// 等待时间超过ctx的截止时间时直接返回，不占用令牌
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		t.cancel()
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		t.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,120 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(20, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.wait(context.Background()); err != nil {
+			t.Fatalf("wait token failed.err:%v", err)
+		}
+	}
+	// 突发2个，之后每50ms一个
+	if cost := time.Since(start); cost < 80*time.Millisecond || cost > time.Second {
+		t.Errorf("token bucket rate not match.cost:%v", cost)
+	}
+
+	bucket = newTokenBucket(1, 1)
+	bucket.wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := bucket.wait(ctx); !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 100*time.Millisecond {
+		t.Errorf("wait should fail fast when deadline is shorter.err:%v", err)
+	}
+	// 失败的等待不占用令牌
+	if bucket.tokens < -0.1 {
+		t.Errorf("token should be returned.tokens:%v", bucket.tokens)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if err := bucket.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait should be canceled.err:%v", err)
+	}
+}
+
+func TestClientLimit(t *testing.T) {
+	var inFlight, maxInFlight, grantCnt int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == AssetApiGrant {
+			atomic.AddInt32(&grantCnt, 1)
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetLimit(&LimitRule{MaxInFlight: 2})
+	cli.SetUriLimit(AssetApiGrant, &LimitRule{MaxInFlight: 1})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resp BaseResp
+			if _, err := cli.Call(context.Background(), AssetApiQueryAsset, "", &resp); err != nil {
+				t.Errorf("call failed.err:%v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("global max in flight not match.max:%d", maxInFlight)
+	}
+
+	atomic.StoreInt32(&maxInFlight, 0)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resp BaseResp
+			cli.Call(context.Background(), AssetApiGrant, "", &resp)
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 1 || grantCnt != 3 {
+		t.Errorf("uri max in flight not match.max:%d cnt:%d", maxInFlight, grantCnt)
+	}
+
+	// 等待令牌时ctx超时
+	cli.SetUriLimit(AssetApiGrant, &LimitRule{Qps: 1})
+	var resp BaseResp
+	cli.Call(context.Background(), AssetApiGrant, "", &resp)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := cli.Call(ctx, AssetApiGrant, "", &resp)
+	if !errors.Is(err, context.DeadlineExceeded) || grantCnt != 4 {
+		t.Errorf("call should be limited.err:%v cnt:%d", err, grantCnt)
+	}
+
+	// 取消限流
+	cli.SetUriLimit(AssetApiGrant, nil)
+	cli.SetLimit(nil)
+	if _, err := cli.Call(context.Background(), AssetApiGrant, "", &resp); err != nil || grantCnt != 5 {
+		t.Errorf("limit should be removed.err:%v cnt:%d", err, grantCnt)
+	}
+}
@@ -0,0 +1,34 @@
+package base
+
+import (
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+)
+
+// SetMetricsCollector 设置接口调用指标收集器，指标以接口常量名（如AssetApiGrant）为endpoint标签
+// nil表示不收集
+func (t *XassetBaseClient) SetMetricsCollector(collector metrics.Collector) {
+	if collector == nil {
+		collector = metrics.NewNoopCollector()
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.metrics = collector
+}
+
+func (t *XassetBaseClient) observeCall(info *callInfo, latency time.Duration, err error) {
+	t.lock.RLock()
+	collector := t.metrics
+	t.lock.RUnlock()
+	if collector == nil {
+		return
+	}
+	collector.Observe(&metrics.Call{
+		Endpoint: ApiName(info.uri),
+		HttpCode: info.httpCode,
+		Errno:    info.errno,
+		Latency:  latency,
+		Failed:   err != nil,
+	})
+}
@@ -0,0 +1,66 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+)
+
+type testCollector struct {
+	calls []*metrics.Call
+}
+
+func (t *testCollector) Observe(call *metrics.Call) {
+	t.calls = append(t.calls, call)
+}
+
+func TestCallMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AssetApiGrant:
+			w.Write([]byte(`{"request_id":"r1","errno":10002}`))
+		case HubCreateOrder:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"request_id":"r2","errno":0}`))
+		}
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	var resp BaseResp
+	// 默认不收集指标
+	cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=1", &resp)
+
+	collector := &testCollector{}
+	cli.SetMetricsCollector(collector)
+	for _, uri := range []string{AssetApiQueryAsset, AssetApiGrant, HubCreateOrder} {
+		resp = BaseResp{}
+		cli.Call(context.Background(), uri, "asset_id=1", &resp)
+	}
+
+	want := []metrics.Call{
+		{Endpoint: "AssetApiQueryAsset", HttpCode: 200, Errno: 0, Failed: false},
+		{Endpoint: "AssetApiGrant", HttpCode: 200, Errno: 10002, Failed: true},
+		{Endpoint: "HubCreateOrder", HttpCode: 502, Errno: 0, Failed: true},
+	}
+	if len(collector.calls) != len(want) {
+		t.Fatalf("metrics count not match.calls:%+v", collector.calls)
+	}
+	for i, w := range want {
+		got := collector.calls[i]
+		if got.Endpoint != w.Endpoint || got.HttpCode != w.HttpCode || got.Errno != w.Errno ||
+			got.Failed != w.Failed || got.Latency <= 0 {
+			t.Errorf("case %d metrics not match.got:%+v", i, got)
+		}
+	}
+
+	cli.SetMetricsCollector(nil)
+	cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=1", &resp)
+	if len(collector.calls) != len(want) {
+		t.Errorf("collector should be removed")
+	}
+}
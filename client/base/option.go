@@ -0,0 +1,118 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IdempotencyKeyHeader 携带调用方指定的幂等键，服务端据此对重复请求去重
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// reservedHeaders 参与签名或由SDK生成的header，不允许通过SetHeader及WithHeader设置
+var reservedHeaders = map[string]struct{}{
+	"Host":           {},
+	"Authorization":  {},
+	"Content-Md5":    {},
+	"Content-Type":   {},
+	"Content-Length": {},
+	"Timestamp":      {},
+}
+
+func isReservedHeader(k string) bool {
+	k = http.CanonicalHeaderKey(strings.TrimSpace(k))
+	if _, ok := reservedHeaders[k]; ok {
+		return true
+	}
+	// x-bce-*前缀的header需要参与签名
+	return strings.HasPrefix(k, "X-Bce-")
+}
+
+// RequestOption 单次请求的可选项，只影响本次调用，不修改客户端的共享配置
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotent bool
+	header     map[string]string
+	timeout    time.Duration
+	// 不合法的可选项，请求不会发出
+	err error
+}
+
+// WithIdempotent 标记请求体携带调用方指定的唯一键，非幂等接口也可以安全重试
+func WithIdempotent() RequestOption {
+	return func(opt *requestOptions) {
+		opt.idempotent = true
+	}
+}
+
+// WithHeader 本次请求额外携带的header，与SetHeader设置的header同名时覆盖
+// 额外header不参与签名，设置Host、Authorization、Content-Md5等签名相关header及x-bce-*时请求返回ComErrParamInvalid
+func WithHeader(k, v string) RequestOption {
+	return func(opt *requestOptions) {
+		if isReservedHeader(k) {
+			opt.err = fmt.Errorf("header %s is reserved for signing", k)
+			return
+		}
+		if opt.header == nil {
+			opt.header = make(map[string]string)
+		}
+		opt.header[k] = v
+	}
+}
+
+// WithTimeout 本次调用的整体超时，包含限流等待及重试，ctx的deadline更早时以ctx为准
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(opt *requestOptions) {
+		opt.timeout = timeout
+	}
+}
+
+// WithIdempotencyKey 通过Idempotency-Key header携带幂等键，重试时使用同一个键。key为空时不生效
+// 幂等键不改变重试范围，非幂等接口仍然不重试，确认服务端按幂等键去重后可以同时使用WithIdempotent
+func WithIdempotencyKey(key string) RequestOption {
+	return func(opt *requestOptions) {
+		if key != "" {
+			WithHeader(IdempotencyKeyHeader, key)(opt)
+		}
+	}
+}
+
+type requestOptionsKey struct{}
+
+// ContextWithOptions 将请求可选项存入ctx，用于AssetOper、StoreOper等以ctx为参数的接口方法，如
+//
+//	ctx = base.ContextWithOptions(ctx, base.WithHeader("X-Biz-Id", "1"), base.WithTimeout(time.Second))
+//	resp, res, err := cli.QueryAssetCtx(ctx, param)
+func ContextWithOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	prev, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	merged := make([]RequestOption, 0, len(prev)+len(opts))
+	merged = append(merged, prev...)
+	for _, opt := range opts {
+		if opt != nil {
+			merged = append(merged, opt)
+		}
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, merged)
+}
+
+// newRequestOptions 依次应用ctx中的可选项及显式传入的可选项
+func newRequestOptions(ctx context.Context, opts []RequestOption) *requestOptions {
+	reqOpt := &requestOptions{}
+	if prev, ok := ctx.Value(requestOptionsKey{}).([]RequestOption); ok {
+		for _, opt := range prev {
+			opt(reqOpt)
+		}
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(reqOpt)
+		}
+	}
+	return reqOpt
+}
@@ -0,0 +1,206 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/common/metrics"
+	"github.com/xuperchain/xasset-sdk-go/common/trace"
+)
+
+// newEchoServer 校验签名，并检查X-Call-Id header与请求体中的call_id一致
+func newEchoServer(t *testing.T) (*httptest.Server, *int32) {
+	var mismatch int32
+	cred := TestGetXassetConfig().Credentials
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("Host", r.Host)
+		if err := auth.CheckSign(r, cred); err != nil {
+			atomic.AddInt32(&mismatch, 1)
+		}
+		r.ParseForm()
+		if r.Header.Get("X-Call-Id") != r.PostForm.Get("call_id") {
+			atomic.AddInt32(&mismatch, 1)
+		}
+		w.Write([]byte(`{"errno":0,"request_id":"` + r.PostForm.Get("call_id") + `"}`))
+	}))
+	return srv, &mismatch
+}
+
+func TestConcurrentCall(t *testing.T) {
+	srv, mismatch := newEchoServer(t)
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	var wg sync.WaitGroup
+	// 请求过程中并发修改客户端的共享设置
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			cli.SetHeader("X-Shared", fmt.Sprint(i))
+			cli.SetRetryPolicy(testRetryPolicy())
+			cli.SetTracer(trace.NewTracer(nil))
+			cli.SetMetricsCollector(metrics.NewNoopCollector())
+			cli.SetCircuitBreaker(NewCircuitBreaker(nil))
+			cli.SetLimit(&LimitRule{MaxInFlight: 100})
+			cli.AddInterceptor(&Interceptor{Name: "noop"})
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	var failed int32
+	var calls sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		calls.Add(1)
+		go func(g int) {
+			defer calls.Done()
+			for i := 0; i < 10; i++ {
+				id := fmt.Sprintf("%d-%d", g, i)
+				var resp BaseResp
+				body := url.Values{"call_id": {id}}.Encode()
+				_, err := cli.Call(context.Background(), AssetApiQueryAsset, body, &resp,
+					WithHeader("X-Call-Id", id))
+				if err != nil || resp.RequestId != id {
+					atomic.AddInt32(&failed, 1)
+				}
+			}
+		}(g)
+	}
+	calls.Wait()
+	close(stop)
+	wg.Wait()
+
+	if atomic.LoadInt32(&failed) != 0 || atomic.LoadInt32(mismatch) != 0 {
+		t.Errorf("concurrent call failed.[failed:%d] [mismatch:%d]", failed, *mismatch)
+	}
+}
+
+func TestRequestOptionHeader(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetHeader("X-Shared", "shared")
+	cli.SetHeader("X-Override", "shared")
+	ctx := ContextWithOptions(context.Background(), WithHeader("X-Ctx", "ctx"), WithHeader("X-Override", "ctx"))
+	if _, err := cli.PostCtx(ctx, AssetApiQueryAsset, "", WithHeader("X-Override", "call")); err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+	if got.Get("X-Shared") != "shared" || got.Get("X-Ctx") != "ctx" || got.Get("X-Override") != "call" {
+		t.Errorf("request header not match.header:%v", got)
+	}
+
+	// 单次请求的header不影响之后的请求
+	if _, err := cli.Post(AssetApiQueryAsset, ""); err != nil {
+		t.Fatalf("post failed.err:%v", err)
+	}
+	if got.Get("X-Ctx") != "" || got.Get("X-Override") != "shared" {
+		t.Errorf("per call header leaked.header:%v", got)
+	}
+}
+
+func TestRequestOptionTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	start := time.Now()
+	_, err := cli.PostCtx(context.Background(), AssetApiQueryAsset, "", WithTimeout(100*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+		t.Errorf("request should time out.err:%v cost:%v", err, time.Since(start))
+	}
+
+	ctx := ContextWithOptions(context.Background(), WithTimeout(100*time.Millisecond))
+	var resp BaseResp
+	if _, err := cli.Call(ctx, AssetApiQueryAsset, "", &resp); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("call should time out.err:%v", err)
+	}
+}
+
+func TestRequestOptionReservedHeader(t *testing.T) {
+	var cnt int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&cnt, 1)
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	for _, k := range []string{"Host", "authorization", "content-md5", "Timestamp", "x-bce-date"} {
+		_, err := cli.PostCtx(context.Background(), AssetApiQueryAsset, "", WithHeader(k, "1"))
+		if !errors.Is(err, ComErrParamInvalid) {
+			t.Errorf("reserved header should be rejected.[header:%s] [err:%v]", k, err)
+		}
+		ctx := ContextWithOptions(context.Background(), WithHeader(k, "1"))
+		var resp BaseResp
+		if _, err := cli.Call(ctx, AssetApiQueryAsset, "", &resp); !errors.Is(err, ComErrParamInvalid) {
+			t.Errorf("reserved header in ctx should be rejected.[header:%s] [err:%v]", k, err)
+		}
+	}
+	if atomic.LoadInt32(&cnt) != 0 {
+		t.Errorf("request with reserved header should not be sent.cnt:%d", cnt)
+	}
+
+	for _, k := range []string{"Host", "Authorization", "x-bce-date"} {
+		if err := cli.SetHeader(k, "1"); !errors.Is(err, ComErrParamInvalid) {
+			t.Errorf("reserved shared header should be rejected.[header:%s] [err:%v]", k, err)
+		}
+	}
+	if _, err := cli.Post(AssetApiQueryAsset, ""); err != nil || atomic.LoadInt32(&cnt) != 1 {
+		t.Errorf("rejected shared header should not affect requests.err:%v", err)
+	}
+}
+
+func TestRequestOptionIdempotencyKey(t *testing.T) {
+	srv, cnt := newRetryServer(t, 2, 503, "")
+	defer srv.Close()
+	var keys []string
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetRetryPolicy(testRetryPolicy())
+	cli.AddInterceptor(&Interceptor{
+		Name: "key",
+		BeforeSend: func(req *http.Request) (*RequestRes, error) {
+			keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+			return nil, nil
+		},
+	})
+
+	// 幂等键不放开非幂等接口的重试
+	res, err := cli.PostCtx(context.Background(), AssetApiGrant, "asset_id=1", WithIdempotencyKey("order-1"))
+	if err != nil || res.HttpCode != 503 || atomic.LoadInt32(cnt) != 1 {
+		t.Fatalf("idempotency key should not enable retry.err:%v res:%+v cnt:%d", err, res, *cnt)
+	}
+	// 重试使用同一个幂等键
+	res, err = cli.PostCtx(context.Background(), AssetApiGrant, "asset_id=1",
+		WithIdempotencyKey("order-2"), WithIdempotent())
+	if err != nil || res.HttpCode != 200 {
+		t.Fatalf("idempotent grant should retry.err:%v res:%+v", err, res)
+	}
+	if fmt.Sprint(keys) != "[order-1 order-2 order-2]" {
+		t.Errorf("idempotency key not match.keys:%v", keys)
+	}
+}
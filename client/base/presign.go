@@ -0,0 +1,68 @@
+package base
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 只读接口，只有这些接口允许生成预签名地址
+var readOnlyApis = map[string]struct{}{
+	AssetApiQueryAsset:       {},
+	AssetApiQueryShard:       {},
+	AssetApiListShardsByAddr: {},
+	AssetApiListAssetByAddr:  {},
+	AssetApiListDiffByAddr:   {},
+	AssetApiGetEvidenceInfo:  {},
+	StoreApiQuery:            {},
+	StoreApiList:             {},
+	StoreApiQueryAct:         {},
+	StoreApiListAct:          {},
+	StoreApiQueryAst:         {},
+	StoreApiListAst:          {},
+}
+
+func IsReadOnlyApi(uri string) bool {
+	_, ok := readOnlyApis[uri]
+	return ok
+}
+
+// PresignGet 生成只读接口的GET预签名地址，请求参数放在query中，持有地址的前端或合作方
+// 在有效期内不需要AK/SK即可访问。expire<=0时使用默认有效期，精确到秒，最短1秒，最长auth.PresignMaxExpireSeconds秒
+// 需要服务端支持以GET方式及query中的authorization参数访问该接口
+func (t *XassetBaseClient) PresignGet(uri string, params url.Values, expire time.Duration) (string, error) {
+	if !IsReadOnlyApi(uri) {
+		t.Logger.Warn("presign url for non read only api.[uri:%s]", uri)
+		return "", ComErrParamInvalid
+	}
+	// 签名有效期精确到秒，不足1秒时会被当作未设置而使用默认有效期
+	if expire > 0 && expire < time.Second {
+		t.Logger.Warn("presign expire less than 1s.[uri:%s] [expire:%v]", uri, expire)
+		return "", ComErrParamInvalid
+	}
+	cred, err := t.GetCredentials()
+	if err != nil {
+		return "", ComErrGetCredentialsFailed
+	}
+
+	endpoint := t.GetConfig().Endpoint
+	if t.endpoints != nil {
+		if ep := t.endpoints.pick(nil); ep != nil {
+			endpoint = ep.url
+		}
+	}
+	reqUrl := fmt.Sprintf("%s%s", endpoint, uri)
+	if len(params) > 0 {
+		reqUrl += "?" + params.Encode()
+	}
+	signed, err := auth.PresignUrl(reqUrl, cred, &auth.SignOptions{
+		ExpireSeconds: int(expire / time.Second),
+	})
+	if err != nil {
+		t.Logger.Warn("presign url failed.[uri:%s] [err:%v]", uri, err)
+		return "", ComErrXassetSignFailed
+	}
+	return signed, nil
+}
@@ -0,0 +1,57 @@
+package base
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+func TestPresignGet(t *testing.T) {
+	cred := TestGetXassetConfig().Credentials
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || auth.CheckSign(r, cred) != nil {
+			w.WriteHeader(401)
+			return
+		}
+		w.Write([]byte(`{"errno":0,"meta":{"asset_id":` + r.URL.Query().Get("asset_id") + `}}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	signed, err := cli.PresignGet(AssetApiQueryAsset, url.Values{"asset_id": {"123"}}, 10*time.Minute)
+	if err != nil || !strings.HasPrefix(signed, srv.URL+AssetApiQueryAsset+"?") ||
+		!strings.Contains(signed, "%2F600%2Fhost%2F") {
+		t.Fatalf("presign get failed.url:%s err:%v", signed, err)
+	}
+
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatalf("get presigned url failed.err:%v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 || !strings.Contains(string(body), `"asset_id":123`) {
+		t.Errorf("presigned url should be verified.code:%d body:%s", resp.StatusCode, body)
+	}
+
+	if _, err := cli.PresignGet(AssetApiGrant, nil, time.Minute); err != ComErrParamInvalid {
+		t.Errorf("presign non read only api should fail.err:%v", err)
+	}
+	if _, err := cli.PresignGet(AssetApiQueryAsset, nil, 2*time.Hour); err != ComErrXassetSignFailed {
+		t.Errorf("presign expire over max should fail.err:%v", err)
+	}
+	// 不足1秒的有效期不能退化为默认有效期
+	if _, err := cli.PresignGet(AssetApiQueryAsset, nil, 500*time.Millisecond); err != ComErrParamInvalid {
+		t.Errorf("presign expire less than 1s should fail.err:%v", err)
+	}
+	signed, err = cli.PresignGet(AssetApiQueryAsset, nil, time.Second)
+	if err != nil || !strings.Contains(signed, "%2F1%2Fhost%2F") {
+		t.Errorf("presign expire 1s failed.url:%s err:%v", signed, err)
+	}
+}
@@ -0,0 +1,49 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/internal/testutil"
+)
+
+func TestRedactCallLogs(t *testing.T) {
+	cases := []struct {
+		code int
+		body string
+	}{
+		{200, testutil.RedactBody(0)},
+		{500, testutil.RedactBody(0)},
+		{200, testutil.RedactBody(10001)},
+		{200, "not json " + testutil.RedactBody(0)},
+	}
+	for i, c := range cases {
+		var author string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			author = r.Header.Get("Authorization")
+			w.WriteHeader(c.code)
+			w.Write([]byte(c.body))
+		}))
+		cfg := TestGetXassetConfig()
+		cfg.Endpoint = srv.URL
+		cfg.SetCredentials(1, "ak", testutil.RedactSK)
+		logger := &testutil.CaptureLogger{}
+		cli := &XassetBaseClient{}
+		if err := cli.InitClient(cfg, logger); err != nil {
+			t.Fatalf("init client failed.err:%v", err)
+		}
+
+		var resp GetStokenResp
+		body := "mnemonic=" + testutil.RedactMnemonic
+		cli.Call(context.Background(), FileApiGetStoken, body, &resp)
+		srv.Close()
+		if author == "" || len(logger.Lines()) == 0 {
+			t.Fatalf("case %d request or log missing", i)
+		}
+		testutil.AssertNoSecret(t, logger.Lines(), testutil.RedactSK, testutil.RedactStsSK,
+			testutil.RedactSessionToken, testutil.RedactMnemonic, author[strings.LastIndex(author, "/")+1:])
+	}
+}
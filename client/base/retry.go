@@ -0,0 +1,97 @@
+package base
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+)
+
+const (
+	RetryMaxAttemptsDef = 3
+	RetryBaseDelayMsDef = 100
+	RetryMaxDelayMsDef  = 2000
+)
+
+// 非幂等接口，重复请求可能产生重复数据，只有调用方指定了唯一键时才允许重试
+var nonIdempotentApis = map[string]struct{}{
+	AssetApiCreate:       {},
+	AssetApiGrant:        {},
+	AssetApiGrantBox:     {},
+	AssetApiComposeShard: {},
+	HubCreateOrder:       {},
+	HubConfirmOrder:      {},
+	CreateRefund:         {},
+}
+
+func IsIdempotentApi(uri string) bool {
+	_, ok := nonIdempotentApis[uri]
+	return !ok
+}
+
+// RetryPolicy 请求失败重试策略
+type RetryPolicy struct {
+	// 最大请求次数，包含首次请求
+	MaxAttempts int
+	// 指数退避的初始间隔和最大间隔，实际间隔在[delay/2, delay]之间随机
+	BaseDelayMs int
+	MaxDelayMs  int
+	// 需要重试的http状态码
+	RetryHttpCodes []int
+	// 需要重试的服务端错误码，默认不按错误码重试
+	RetryErrnos []int
+}
+
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    RetryMaxAttemptsDef,
+		BaseDelayMs:    RetryBaseDelayMsDef,
+		MaxDelayMs:     RetryMaxDelayMsDef,
+		RetryHttpCodes: []int{502, 503, 504},
+	}
+}
+
+// shouldRetry 网络错误、命中重试状态码或重试错误码时重试，context结束时不重试
+func (t *RetryPolicy) shouldRetry(res *RequestRes, err error) bool {
+	if err != nil {
+		return err == ComErrRequsetFailed
+	}
+	if res == nil {
+		return false
+	}
+	for _, code := range t.RetryHttpCodes {
+		if res.HttpCode == code {
+			return true
+		}
+	}
+	if res.HttpCode != 200 || len(t.RetryErrnos) == 0 {
+		return false
+	}
+
+	var resp BaseResp
+	if err := json.Unmarshal([]byte(res.Body), &resp); err != nil {
+		return false
+	}
+	for _, errno := range t.RetryErrnos {
+		if resp.Errno == errno {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff 第attempt次请求失败后的等待时间
+func (t *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := int64(t.BaseDelayMs)
+	for i := 1; i < attempt && delay < int64(t.MaxDelayMs); i++ {
+		delay *= 2
+	}
+	if t.MaxDelayMs > 0 && delay > int64(t.MaxDelayMs) {
+		delay = int64(t.MaxDelayMs)
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return time.Duration(half+rand.Int63n(delay-half+1)) * time.Millisecond
+}
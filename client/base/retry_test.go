@@ -0,0 +1,167 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+func newRetryServer(t *testing.T, failTimes int32, failCode int, failBody string) (*httptest.Server, *int32) {
+	var cnt int32
+	cred := TestGetXassetConfig().Credentials
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set("Host", r.Host)
+		if err := auth.CheckSign(r, cred); err != nil {
+			t.Errorf("retry request sign invalid.err:%v", err)
+		}
+		if atomic.AddInt32(&cnt, 1) <= failTimes {
+			w.WriteHeader(failCode)
+			w.Write([]byte(failBody))
+			return
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}))
+	return srv, &cnt
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := NewRetryPolicy()
+	policy.BaseDelayMs = 1
+	policy.MaxDelayMs = 5
+	return policy
+}
+
+func TestPostRetryHttpCode(t *testing.T) {
+	srv, cnt := newRetryServer(t, 2, 503, "")
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetRetryPolicy(testRetryPolicy())
+	res, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+	if err != nil || res.HttpCode != 200 {
+		t.Fatalf("post with retry failed.err:%v res:%+v", err, res)
+	}
+	if atomic.LoadInt32(cnt) != 3 {
+		t.Fatalf("unexpected attempts.cnt:%d", *cnt)
+	}
+}
+
+func TestPostRetryExhausted(t *testing.T) {
+	srv, cnt := newRetryServer(t, 10, 502, "")
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetRetryPolicy(testRetryPolicy())
+	res, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+	if err != nil || res.HttpCode != 502 {
+		t.Fatalf("post want last failed response.err:%v res:%+v", err, res)
+	}
+	if atomic.LoadInt32(cnt) != RetryMaxAttemptsDef {
+		t.Fatalf("unexpected attempts.cnt:%d", *cnt)
+	}
+}
+
+func TestPostRetryErrno(t *testing.T) {
+	srv, cnt := newRetryServer(t, 1, 200, `{"errno":10001}`)
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	policy := testRetryPolicy()
+	policy.RetryErrnos = []int{10001}
+	cli.SetRetryPolicy(policy)
+	res, err := cli.Post(AssetApiQueryAsset, "asset_id=1")
+	if err != nil || res.Body != `{"errno":0}` {
+		t.Fatalf("post with errno retry failed.err:%v res:%+v", err, res)
+	}
+	if atomic.LoadInt32(cnt) != 2 {
+		t.Fatalf("unexpected attempts.cnt:%d", *cnt)
+	}
+}
+
+func TestPostRetryNonIdempotent(t *testing.T) {
+	srv, cnt := newRetryServer(t, 2, 503, "")
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetRetryPolicy(testRetryPolicy())
+	res, err := cli.Post(AssetApiGrant, "asset_id=1&shard_id=2")
+	if err != nil || res.HttpCode != 503 {
+		t.Fatalf("non idempotent api should not retry.err:%v res:%+v", err, res)
+	}
+
+	res, err = cli.PostCtx(context.Background(), AssetApiGrant, "asset_id=1&shard_id=2", WithIdempotent())
+	if err != nil || res.HttpCode != 200 {
+		t.Fatalf("idempotent grant should retry.err:%v res:%+v", err, res)
+	}
+	if atomic.LoadInt32(cnt) != 3 {
+		t.Fatalf("unexpected attempts.cnt:%d", *cnt)
+	}
+}
+
+func TestPostRetryVerifier(t *testing.T) {
+	var cnt int32
+	cfg := TestGetXassetConfig()
+	verifier := auth.NewVerifier(auth.NewCredentialsLookup(cfg.Credentials), nil)
+	srv := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&cnt, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte(`{"errno":0}`))
+	}), nil))
+	defer srv.Close()
+
+	// 重试的签名及nonce与首次请求相同，失败的请求不消耗签名及nonce
+	cli := newTestBaseClient(t, srv.URL)
+	cli.SetRetryPolicy(testRetryPolicy())
+	res, err := cli.Post(AssetApiQueryAsset, "asset_id=1&nonce=1")
+	if err != nil || res.HttpCode != 200 {
+		t.Fatalf("retry should pass verifier.err:%v res:%+v", err, res)
+	}
+	if atomic.LoadInt32(&cnt) != 2 {
+		t.Fatalf("unexpected attempts.cnt:%d", cnt)
+	}
+
+	// 成功处理过的nonce不能再次使用
+	res, err = cli.Post(AssetApiQueryAsset, "asset_id=1&nonce=1")
+	if err != nil || res.HttpCode != 401 {
+		t.Errorf("replayed nonce should be rejected.err:%v res:%+v", err, res)
+	}
+}
+
+func TestPostRetryCtxDone(t *testing.T) {
+	srv, _ := newRetryServer(t, 10, 503, "")
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	policy := testRetryPolicy()
+	policy.MaxAttempts = 100
+	policy.BaseDelayMs, policy.MaxDelayMs = 1000, 1000
+	cli.SetRetryPolicy(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := cli.PostCtx(ctx, AssetApiQueryAsset, "asset_id=1")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("retry should stop when ctx done.err:%v", err)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelayMs: 100, MaxDelayMs: 1000}
+	for attempt := 1; attempt < 10; attempt++ {
+		want := 100 << uint(attempt-1)
+		if want > 1000 {
+			want = 1000
+		}
+		d := policy.backoff(attempt)
+		if d < time.Duration(want/2)*time.Millisecond || d > time.Duration(want)*time.Millisecond {
+			t.Fatalf("backoff out of range.attempt:%d delay:%v", attempt, d)
+		}
+	}
+}
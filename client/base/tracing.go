@@ -0,0 +1,66 @@
+package base
+
+import (
+	"context"
+
+	"github.com/xuperchain/xasset-sdk-go/common/trace"
+)
+
+// span属性名
+const (
+	SpanAttrUri      = "xasset.uri"
+	SpanAttrAssetId  = "xasset.asset_id"
+	SpanAttrShardId  = "xasset.shard_id"
+	SpanAttrErrno    = "xasset.errno"
+	SpanAttrTraceId  = "xasset.trace_id"
+	SpanAttrHttpCode = "http.status_code"
+)
+
+// SetTracer 开启链路追踪，每次接口调用创建一个以接口常量名（如AssetApiGrant）命名的span，
+// 并通过traceparent header向服务端传递追踪上下文。nil表示关闭
+func (t *XassetBaseClient) SetTracer(tracer trace.Tracer) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tracer = tracer
+}
+
+func (t *XassetBaseClient) startSpan(ctx context.Context, uri string) (context.Context, trace.Span) {
+	t.lock.RLock()
+	tracer := t.tracer
+	t.lock.RUnlock()
+	if tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := tracer.Start(ctx, ApiName(uri))
+	// 自定义Tracer未将span存入ctx时，保证请求能够传递追踪上下文
+	if trace.SpanFromContext(ctx) != span {
+		ctx = trace.ContextWithSpan(ctx, span)
+	}
+	return ctx, span
+}
+
+func (t *XassetBaseClient) endSpan(span trace.Span, info *callInfo, err error) {
+	if span == nil {
+		return
+	}
+
+	attrs := []trace.Attribute{
+		trace.String(SpanAttrUri, info.uri),
+		trace.Int(SpanAttrErrno, info.errno),
+	}
+	if info.assetId != "" {
+		attrs = append(attrs, trace.String(SpanAttrAssetId, info.assetId))
+	}
+	if info.shardId != "" {
+		attrs = append(attrs, trace.String(SpanAttrShardId, info.shardId))
+	}
+	if info.traceId != "" {
+		attrs = append(attrs, trace.String(SpanAttrTraceId, info.traceId))
+	}
+	if info.httpCode != 0 {
+		attrs = append(attrs, trace.Int(SpanAttrHttpCode, info.httpCode))
+	}
+	span.SetAttributes(attrs...)
+	span.RecordError(err)
+	span.End()
+}
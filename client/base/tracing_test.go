@@ -0,0 +1,87 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/common/trace"
+)
+
+func TestCallTracing(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get(trace.TraceparentHeader)
+		w.Header().Set(TraceIdHeader, "server-trace")
+		if r.URL.Path == AssetApiGrant {
+			w.Write([]byte(`{"request_id":"r1","errno":10002,"errmsg":"param invalid"}`))
+			return
+		}
+		w.Write([]byte(`{"request_id":"r2","errno":0}`))
+	}))
+	defer srv.Close()
+
+	cli := newTestBaseClient(t, srv.URL)
+	exporter := trace.NewInMemoryExporter()
+	tracer := trace.NewTracer(exporter)
+	cli.SetTracer(tracer)
+
+	ctx, parent := tracer.Start(context.Background(), "marketplace")
+	var resp BaseResp
+	_, err := cli.Call(ctx, AssetApiGrant, "asset_id=11&shard_id=22", &resp)
+	if !errors.Is(err, ComErrServRespErrnoErr) {
+		t.Fatalf("call grant err not match.err:%v", err)
+	}
+	spans := exporter.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("span count not match.spans:%+v", spans)
+	}
+	span := spans[0]
+	if span.Name != "AssetApiGrant" || span.Parent.SpanId != parent.SpanContext().SpanId ||
+		span.SpanContext.TraceId != parent.SpanContext().TraceId || span.Err == nil {
+		t.Errorf("span not match.span:%+v", span)
+	}
+	want := map[string]interface{}{
+		SpanAttrUri:      AssetApiGrant,
+		SpanAttrAssetId:  "11",
+		SpanAttrShardId:  "22",
+		SpanAttrErrno:    10002,
+		SpanAttrTraceId:  "server-trace",
+		SpanAttrHttpCode: 200,
+	}
+	for k, v := range want {
+		if span.Attr(k) != v {
+			t.Errorf("span attr %s not match.want:%v got:%v", k, v, span.Attr(k))
+		}
+	}
+	if traceparent != trace.FormatTraceparent(span.SpanContext) {
+		t.Errorf("traceparent not match.header:%s span:%+v", traceparent, span.SpanContext)
+	}
+
+	exporter.Reset()
+	resp = BaseResp{}
+	if _, err := cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=11", &resp); err != nil {
+		t.Fatalf("call query failed.err:%v", err)
+	}
+	spans = exporter.Spans()
+	if len(spans) != 1 || spans[0].Name != "AssetApiQueryAsset" || spans[0].Parent.IsValid() ||
+		spans[0].Err != nil || spans[0].Attr(SpanAttrShardId) != nil {
+		t.Errorf("root span not match.spans:%+v", spans)
+	}
+
+	cli.SetTracer(nil)
+	traceparent = ""
+	cli.Call(context.Background(), AssetApiQueryAsset, "asset_id=11", &resp)
+	if traceparent != "" || len(exporter.Spans()) != 1 {
+		t.Errorf("tracing should be disabled.traceparent:%s", traceparent)
+	}
+}
+
+func TestApiName(t *testing.T) {
+	if ApiName(AssetApiGrant) != "AssetApiGrant" || ApiName(HubCreateOrder) != "HubCreateOrder" ||
+		ApiName("/unknown") != "/unknown" {
+		t.Errorf("api name not match")
+	}
+}
@@ -1,15 +1,16 @@
 package xasset
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	auth2 "github.com/baidubce/bce-sdk-go/auth"
 	"github.com/baidubce/bce-sdk-go/services/bos"
 
-	"github.com/xuperchain/xasset-sdk-go/auth"
 	xbase "github.com/xuperchain/xasset-sdk-go/client/base"
 	"github.com/xuperchain/xasset-sdk-go/common/config"
 	"github.com/xuperchain/xasset-sdk-go/common/logs"
@@ -38,83 +39,69 @@ func NewAssetOperCli(cfg *config.XassetCliConfig, logger logs.LogDriver) (*Asset
 //			   PKey     string `json:"pkey"`
 //			   Nonce    int64  `json:"nonce"`
 //		  }
-func (t *AssetOper) genGetStokenBody(param *xbase.GetStokenParam) (string, error) {
+func (t *AssetOper) genGetStokenBody(ctx context.Context, param *xbase.GetStokenParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d", nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
 }
 
 func (t *AssetOper) GetStoken(param *xbase.GetStokenParam) (*xbase.GetStokenResp, *xbase.RequestRes, error) {
+	return t.GetStokenCtx(context.Background(), param)
+}
+
+func (t *AssetOper) GetStokenCtx(ctx context.Context, param *xbase.GetStokenParam) (*xbase.GetStokenResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 	}
 
-	body, err := t.genGetStokenBody(param)
+	body, err := t.genGetStokenBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for getting stoken, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.FileApiGetStoken, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 	}
-
 	var resp xbase.GetStokenResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.FileApiGetStoken, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [accessInfo: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AccessInfo, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileResp, *xbase.RequestRes, error) {
+	return t.UploadFileCtx(context.Background(), param)
+}
+
+func (t *AssetOper) UploadFileCtx(ctx context.Context, param *xbase.UploadFileParam) (*xbase.UploadFileResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 	}
 
-	resp, res, err := t.GetStoken(&xbase.GetStokenParam{Account: param.Account})
+	resp, res, err := t.GetStokenCtx(ctx, &xbase.GetStokenParam{Account: param.Account})
 	if err != nil {
-		t.Logger.Warn("get stoken failed.[url:%s] [request_id:%s] [err_no:%d] [trace_id:%s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, nil, err
+		t.Logger.Warn("get stoken failed.[err:%v]", err)
+		return nil, res, err
 	}
 
 	bosClient, err := bos.NewClient(resp.AccessInfo.AK, resp.AccessInfo.SK, resp.AccessInfo.EndPoint)
 	if err != nil {
 		t.Logger.Warn("create bos client failed.err:%v", err)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 	}
 	stsCredential, err := auth2.NewSessionBceCredentials(resp.AccessInfo.AK, resp.AccessInfo.SK, resp.AccessInfo.SessionToken)
 	if err != nil {
 		t.Logger.Warn("create sts credential object failed.err:%v", err)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 	}
 	bosClient.Config.Credentials = stsCredential
 
@@ -124,17 +111,17 @@ func (t *AssetOper) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileR
 		_, err = bosClient.PutObjectFromFile(resp.AccessInfo.Bucket, key, param.FilePath, nil)
 		if err != nil {
 			t.Logger.Warn("upload file through local file failed.err:%v", err)
-			return nil, nil, err
+			return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 		}
 	} else if param.DataByte != nil {
 		_, err = bosClient.PutObjectFromBytes(resp.AccessInfo.Bucket, key, param.DataByte, nil)
 		if err != nil {
 			t.Logger.Warn("upload file through bytes failed.err:%v", err)
-			return nil, nil, err
+			return nil, nil, xbase.NewXassetError(err, xbase.FileApiGetStoken)
 		}
 	} else {
 		t.Logger.Warn("unsupported upload file method")
-		return nil, nil, fmt.Errorf("wrong upload file method")
+		return nil, nil, xbase.NewXassetError(fmt.Errorf("wrong upload file method"), xbase.FileApiGetStoken)
 	}
 
 	link := fmt.Sprintf("bos_v1://%s%s/%s", resp.AccessInfo.Bucket, key, param.Property)
@@ -161,7 +148,7 @@ func (t *AssetOper) UploadFile(param *xbase.UploadFileParam) (*xbase.UploadFileR
 //			UserId    int64  `json:"user_id,omitempty"`
 //			FileHash  string `json:"file_hash,omitempty"`
 //	}
-func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetParam) (string, error) {
+func (t *AssetOper) genCreateAssetBody(ctx context.Context, appid int64, param *xbase.CreateAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	// generate assetId if not specified
@@ -169,7 +156,7 @@ func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetPara
 		assetId = utils.GenAssetId(appid)
 	}
 	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -183,9 +170,9 @@ func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetPara
 	v.Set("price", fmt.Sprintf("%d", param.Price))
 	v.Set("amount", fmt.Sprintf("%d", param.Amount))
 	v.Set("asset_info", string(assetInfo))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("view_type", fmt.Sprintf("%d", param.ViewType))
 	v.Set("param", param.AssetParam)
@@ -200,41 +187,33 @@ func (t *AssetOper) genCreateAssetBody(appid int64, param *xbase.CreateAssetPara
 }
 
 func (t *AssetOper) CreateAsset(param *xbase.CreateAssetParam) (*xbase.CreateAssetResp, *xbase.RequestRes, error) {
+	return t.CreateAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) CreateAssetCtx(ctx context.Context, param *xbase.CreateAssetParam) (*xbase.CreateAssetResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiCreate)
 	}
 
-	body, err := t.genCreateAssetBody(t.GetConfig().Credentials.AppId, param)
+	cred, err := t.GetCredentials()
 	if err != nil {
-		t.Logger.Warn("fail to generate value for creating, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(xbase.ComErrGetCredentialsFailed, xbase.AssetApiCreate)
 	}
-	res, err := t.Post(xbase.AssetApiCreate, body)
+	body, err := t.genCreateAssetBody(ctx, cred.AppId, param)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiCreate, err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		t.Logger.Warn("fail to generate value for creating, err: %v, param: %+v", err, *param)
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiCreate)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+	// 指定了asset_id时重复创建会被服务端去重，可以安全重试
+	var opts []xbase.RequestOption
+	if param.AssetId > 0 {
+		opts = append(opts, xbase.WithIdempotent())
 	}
-
 	var resp xbase.CreateAssetResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiCreate, body, &resp, opts...)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -250,19 +229,19 @@ func (t *AssetOper) CreateAsset(param *xbase.CreateAssetParam) (*xbase.CreateAss
 //			AssetInfo string `json:"asset_info"`
 //			FileHash  string `json:"file_hash"`
 //	}
-func (t *AssetOper) genAlterAssetBody(param *xbase.AlterAssetParam) (string, error) {
+func (t *AssetOper) genAlterAssetBody(ctx context.Context, param *xbase.AlterAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 
 	if err := xbase.PriceInvalid(param.Price); err == nil {
@@ -291,41 +270,24 @@ func (t *AssetOper) genAlterAssetBody(param *xbase.AlterAssetParam) (string, err
 // AlterAsset Empty price makes the asset with a zero price value. If you don't want to alter the price parameter, set price to -1.
 // Empty amount makes the asset with an endless supply of shards. If you don't want to alter the amount parameter, set amount to -1.
 func (t *AssetOper) AlterAsset(param *xbase.AlterAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.AlterAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) AlterAssetCtx(ctx context.Context, param *xbase.AlterAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiAlter)
 	}
 
-	body, err := t.genAlterAssetBody(param)
+	body, err := t.genAlterAssetBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for altering, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiAlter)
 	}
-	res, err := t.Post(xbase.AssetApiAlter, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiAlter, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -339,19 +301,19 @@ func (t *AssetOper) AlterAsset(param *xbase.AlterAssetParam) (*xbase.BaseResp, *
 //			Nonce      int64  `json:"nonce"`
 //		    IsEvidence int    `json:"is_evidence,omitempty"`
 //	}
-func (t *AssetOper) genPublishAssetBody(param *xbase.PublishAssetParam) (string, error) {
+func (t *AssetOper) genPublishAssetBody(ctx context.Context, param *xbase.PublishAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("is_evidence", fmt.Sprintf("%d", param.IsEvidence))
 	body := v.Encode()
@@ -359,40 +321,23 @@ func (t *AssetOper) genPublishAssetBody(param *xbase.PublishAssetParam) (string,
 }
 
 func (t *AssetOper) PublishAsset(param *xbase.PublishAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.PublishAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) PublishAssetCtx(ctx context.Context, param *xbase.PublishAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiPublish)
 	}
-	body, err := t.genPublishAssetBody(param)
+	body, err := t.genPublishAssetBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for publishing, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiPublish, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiPublish)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiPublish, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -409,40 +354,33 @@ func (t *AssetOper) genQueryAssetBody(param *xbase.QueryAssetParam) (string, err
 }
 
 func (t *AssetOper) QueryAsset(param *xbase.QueryAssetParam) (*xbase.QueryAssetResp, *xbase.RequestRes, error) {
+	return t.QueryAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) QueryAssetCtx(ctx context.Context, param *xbase.QueryAssetParam) (*xbase.QueryAssetResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiQueryAsset)
 	}
 	body, _ := t.genQueryAssetBody(param)
 
-	res, err := t.Post(xbase.AssetApiQueryAsset, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryAssetResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiQueryAsset, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [meta: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.Meta, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
+// PresignQueryAsset 生成查询资产的GET预签名地址，有效期内不需要AK/SK即可访问
+func (t *AssetOper) PresignQueryAsset(param *xbase.QueryAssetParam, expire time.Duration) (string, error) {
+	if err := param.Valid(); err != nil {
+		return "", err
+	}
+	body, _ := t.genQueryAssetBody(param)
+	params, _ := url.ParseQuery(body)
+	return t.PresignGet(xbase.AssetApiQueryAsset, params, expire)
+}
+
 // GenListAssetsByAddrBody uses the general parameter as follows,
 //
 //	   {
@@ -462,37 +400,20 @@ func (t *AssetOper) genListAssetByAddrBody(param *xbase.ListAssetsByAddrParam) (
 }
 
 func (t *AssetOper) ListAssetsByAddr(param *xbase.ListAssetsByAddrParam) (*xbase.ListAssetsByAddrResp, *xbase.RequestRes, error) {
+	return t.ListAssetsByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ListAssetsByAddrCtx(ctx context.Context, param *xbase.ListAssetsByAddrParam) (*xbase.ListAssetsByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiListAssetByAddr)
 	}
 	body, _ := t.genListAssetByAddrBody(param)
 
-	res, err := t.Post(xbase.AssetApiListAssetByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListAssetsByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiListAssetByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [total_cnt: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.TotalCnt,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -521,37 +442,20 @@ func (t *AssetOper) genListDiffByAddrBody(param *xbase.ListDiffByAddrParam) (str
 }
 
 func (t *AssetOper) ListDiffByAddr(param *xbase.ListDiffByAddrParam) (*xbase.ListDiffByAddrResp, *xbase.RequestRes, error) {
+	return t.ListDiffByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ListDiffByAddrCtx(ctx context.Context, param *xbase.ListDiffByAddrParam) (*xbase.ListDiffByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiListDiffByAddr)
 	}
 	body, _ := t.genListDiffByAddrBody(param)
 
-	res, err := t.Post(xbase.AssetApiListDiffByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListDiffByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiListDiffByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -568,10 +472,10 @@ func (t *AssetOper) ListDiffByAddr(param *xbase.ListDiffByAddrParam) (*xbase.Lis
 //			   ToUserId int64  `json:"to_userid,omitempty"`
 //	 	   Price 	int64  `json:"price",omitempty`
 //		  }
-func (t *AssetOper) genGrantAssetBody(appid int64, param *xbase.GrantAssetParam) (string, error) {
+func (t *AssetOper) genGrantAssetBody(ctx context.Context, appid int64, param *xbase.GrantAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -588,7 +492,7 @@ func (t *AssetOper) genGrantAssetBody(appid int64, param *xbase.GrantAssetParam)
 	v.Set("price", fmt.Sprintf("%d", param.Price))
 	v.Set("addr", param.Addr)
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("to_addr", param.ToAddr)
 	v.Set("param", param.ShardParam)
@@ -600,41 +504,33 @@ func (t *AssetOper) genGrantAssetBody(appid int64, param *xbase.GrantAssetParam)
 
 // GrantAsset grants a random shard to the specific address for the very first time after the maker publishes its asset.
 func (t *AssetOper) GrantAsset(param *xbase.GrantAssetParam) (*xbase.GrantAssetResp, *xbase.RequestRes, error) {
+	return t.GrantAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) GrantAssetCtx(ctx context.Context, param *xbase.GrantAssetParam) (*xbase.GrantAssetResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiGrant)
 	}
 
-	body, err := t.genGrantAssetBody(t.GetConfig().Credentials.AppId, param)
+	cred, err := t.GetCredentials()
 	if err != nil {
-		t.Logger.Warn("fail to generate value for granting, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(xbase.ComErrGetCredentialsFailed, xbase.AssetApiGrant)
 	}
-	res, err := t.Post(xbase.AssetApiGrant, body)
+	body, err := t.genGrantAssetBody(ctx, cred.AppId, param)
 	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, asset_id: %d, err: %v", xbase.AssetApiGrant, param.AssetId, err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		t.Logger.Warn("fail to generate value for granting, err: %v, param: %+v", err, *param)
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiGrant)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [asset_id: %d] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, param.AssetId, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+	// 指定了shard_id时重复发放会被服务端去重，可以安全重试
+	var opts []xbase.RequestOption
+	if param.ShardId > 0 {
+		opts = append(opts, xbase.WithIdempotent())
 	}
-
 	var resp xbase.GrantAssetResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiGrant, body, &resp, opts...)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [asset_id: %d] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, param.AssetId, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [asset_id: %d] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, param.AssetId, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [shard_id: %v] [from: %s] [to: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, param.Addr, param.ToAddr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -651,10 +547,10 @@ func (t *AssetOper) GrantAsset(param *xbase.GrantAssetParam) (*xbase.GrantAssetR
 //			   ToAddr   string `json:"to_addr"`
 //			   ToUserId int64  `json:"to_userid,omitempty"`
 //		  }
-func (t *AssetOper) genTransferAssetBody(param *xbase.TransferAssetParam) (string, error) {
+func (t *AssetOper) genTransferAssetBody(ctx context.Context, param *xbase.TransferAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -665,7 +561,7 @@ func (t *AssetOper) genTransferAssetBody(param *xbase.TransferAssetParam) (strin
 	v.Set("price", fmt.Sprintf("%d", param.Price))
 	v.Set("addr", param.Addr)
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	v.Set("to_addr", param.ToAddr)
 	if err := xbase.IdValid(param.ToUserId); err == nil {
@@ -676,41 +572,24 @@ func (t *AssetOper) genTransferAssetBody(param *xbase.TransferAssetParam) (strin
 
 // GrantAsset transfer th specific shard from address A to address B.
 func (t *AssetOper) TransferAsset(param *xbase.TransferAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.TransferAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) TransferAssetCtx(ctx context.Context, param *xbase.TransferAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiTransfer)
 	}
 
-	body, err := t.genTransferAssetBody(param)
+	body, err := t.genTransferAssetBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for transferring, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiTransfer)
 	}
-	res, err := t.Post(xbase.AssetApiTransfer, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiTransfer, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiTransfer, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [from: %s] [to: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, param.Addr, param.ToAddr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -729,40 +608,33 @@ func (t *AssetOper) genQueryShardsBody(param *xbase.QueryShardParam) (string, er
 }
 
 func (t *AssetOper) QueryShard(param *xbase.QueryShardParam) (*xbase.QueryShardResp, *xbase.RequestRes, error) {
+	return t.QueryShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) QueryShardCtx(ctx context.Context, param *xbase.QueryShardParam) (*xbase.QueryShardResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiQueryShard)
 	}
 	body, _ := t.genQueryShardsBody(param)
 
-	res, err := t.Post(xbase.AssetApiQueryShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryShardResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiQueryShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [meta:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Meta, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
+// PresignQueryShard 生成查询碎片的GET预签名地址，有效期内不需要AK/SK即可访问
+func (t *AssetOper) PresignQueryShard(param *xbase.QueryShardParam, expire time.Duration) (string, error) {
+	if err := param.Valid(); err != nil {
+		return "", err
+	}
+	body, _ := t.genQueryShardsBody(param)
+	params, _ := url.ParseQuery(body)
+	return t.PresignGet(xbase.AssetApiQueryShard, params, expire)
+}
+
 // GenListShardsByAddrBody uses the general parameter as follows,
 //
 //	   {
@@ -783,37 +655,20 @@ func (t *AssetOper) genListShardsByAddrBody(param *xbase.ListShardsByAddrParam)
 }
 
 func (t *AssetOper) ListShardsByAddr(param *xbase.ListShardsByAddrParam) (*xbase.ListShardsByAddrResp, *xbase.RequestRes, error) {
+	return t.ListShardsByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ListShardsByAddrCtx(ctx context.Context, param *xbase.ListShardsByAddrParam) (*xbase.ListShardsByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiListShardsByAddr)
 	}
 	body, _ := t.genListShardsByAddrBody(param)
 
-	res, err := t.Post(xbase.AssetApiListShardsByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListShardsByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiListShardsByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [total_cnt: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.TotalCnt,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -834,43 +689,30 @@ func (t *AssetOper) genListShardsByAssetBody(param *xbase.ListShardsByAssetParam
 }
 
 func (t *AssetOper) ListShardsByAsset(param *xbase.ListShardsByAssetParam) (*xbase.ListShardsByAssetResp, *xbase.RequestRes, error) {
+	return t.ListShardsByAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ListShardsByAssetCtx(ctx context.Context, param *xbase.ListShardsByAssetParam) (*xbase.ListShardsByAssetResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetListShardsByAsset)
 	}
 	body, _ := t.genListShardsByAssetBody(param)
 
-	res, err := t.Post(xbase.AssetListShardsByAsset, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListShardsByAssetResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetListShardsByAsset, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [cursor: %s] [has_more: %d] [url: %s] [request_id: %s] [trace_id: %s]", resp.Cursor, resp.HasMore,
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) ListAssetHistory(param *xbase.ListAssetHisParam) (*xbase.ListAssetHistoryResp, *xbase.RequestRes, error) {
+	return t.ListAssetHistoryCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ListAssetHistoryCtx(ctx context.Context, param *xbase.ListAssetHisParam) (*xbase.ListAssetHistoryResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.ListAssetHistory)
 	}
 
 	v := url.Values{}
@@ -884,26 +726,11 @@ func (t *AssetOper) ListAssetHistory(param *xbase.ListAssetHisParam) (*xbase.Lis
 	}
 	body := v.Encode()
 
-	res, err := t.Post(xbase.ListAssetHistory, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.ListAssetHistory, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListAssetHistoryResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.ListAssetHistory, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s] [resp: %+v]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header), resp)
 	return &resp, res, nil
 }
 
@@ -920,37 +747,20 @@ func (t *AssetOper) genEvidenceBody(param *xbase.GetEvidenceInfoParam) (string,
 }
 
 func (t *AssetOper) GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.GetEvidenceInfoResp, *xbase.RequestRes, error) {
+	return t.GetEvidenceInfoCtx(context.Background(), param)
+}
+
+func (t *AssetOper) GetEvidenceInfoCtx(ctx context.Context, param *xbase.GetEvidenceInfoParam) (*xbase.GetEvidenceInfoResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiGetEvidenceInfo)
 	}
 	body, _ := t.genEvidenceBody(param)
 
-	res, err := t.Post(xbase.AssetApiGetEvidenceInfo, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.GetEvidenceInfoResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiGetEvidenceInfo, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [create_addr: %s] [tx_id: %s] [asset_info: %v] [ctime: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.CreateAddr, resp.TxId, resp.AssetInfo, resp.Ctime, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -958,62 +768,45 @@ func (t *AssetOper) GetEvidenceInfo(param *xbase.GetEvidenceInfoParam) (*xbase.G
 //
 //	   {
 //			   AssetId  int64  			`json:"asset_id"`
-//			   Account  *auth.Account	`json:"account"`
+//			   Account  auth.Signer	`json:"account"`
 //		  }
-func (t *AssetOper) genFreezeAssetBody(param *xbase.FreezeAssetParam) (string, error) {
+func (t *AssetOper) genFreezeAssetBody(ctx context.Context, param *xbase.FreezeAssetParam) (string, error) {
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	return v.Encode(), nil
 }
 
 // FreezeAsset freeze assets where granting action is forbidden.
 func (t *AssetOper) FreezeAsset(param *xbase.FreezeAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.FreezeAssetCtx(context.Background(), param)
+}
+
+func (t *AssetOper) FreezeAssetCtx(ctx context.Context, param *xbase.FreezeAssetParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiFreeze)
 	}
 
-	body, err := t.genFreezeAssetBody(param)
+	body, err := t.genFreezeAssetBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for freeze, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiFreeze, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiFreeze, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiFreeze)
 	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiFreeze, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1041,41 +834,24 @@ func (t *AssetOper) genConsumeShardBody(param *xbase.ConsumeShardParam) (string,
 
 // ConsumeShard consumes shards where any other action is forbidden.
 func (t *AssetOper) ConsumeShard(param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.ConsumeShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) ConsumeShardCtx(ctx context.Context, param *xbase.ConsumeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiConsume)
 	}
 
 	body, err := t.genConsumeShardBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for consume, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiConsume)
 	}
-	res, err := t.Post(xbase.AssetApiConsume, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiConsume, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiConsume, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1094,41 +870,24 @@ func (t *AssetOper) genSelBoxAstBody(param *xbase.SelBoxAstParam) (string, error
 }
 
 func (t *AssetOper) SelectBoxAst(param *xbase.SelBoxAstParam) (*xbase.SelBoxAstResp, *xbase.RequestRes, error) {
+	return t.SelectBoxAstCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SelectBoxAstCtx(ctx context.Context, param *xbase.SelBoxAstParam) (*xbase.SelBoxAstResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiSelectBoxAst)
 	}
 
 	body, err := t.genSelBoxAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for select box asset, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiSelectBoxAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiSelectBoxAst)
 	}
-
 	var resp xbase.SelBoxAstResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiSelectBoxAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [real_asset_id: %d] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.RealAstId, resp.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1136,22 +895,22 @@ func (t *AssetOper) SelectBoxAst(param *xbase.SelBoxAstParam) (*xbase.SelBoxAstR
 //
 //	   {
 //				Token        string
-//				UAccount 	 *auth.Account
-//				CAccount 	 *auth.Account
+//				UAccount 	 auth.Signer
+//				CAccount 	 auth.Signer
 //				AssetId      int64
 //				UserId       int64
 //		  }
-func (t *AssetOper) genGrantBoxBody(param *xbase.GrantBoxParam) (string, error) {
+func (t *AssetOper) genGrantBoxBody(ctx context.Context, param *xbase.GrantBoxParam) (string, error) {
 	consumeNonce := utils.GenNonce()
 	consumeSignMsg := fmt.Sprintf("%d%d", param.BoxAssetId, consumeNonce)
-	uSign, err := auth.XassetSignECDSA(param.UAccount.PrivateKey, []byte(consumeSignMsg))
+	uSign, err := param.UAccount.Sign(ctx, []byte(consumeSignMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 
 	grantNonce := utils.GenNonce()
 	grantSignMsg := fmt.Sprintf("%d%d", param.RealAssetId, grantNonce)
-	cSign, err := auth.XassetSignECDSA(param.CAccount.PrivateKey, []byte(grantSignMsg))
+	cSign, err := param.CAccount.Sign(ctx, []byte(grantSignMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1162,12 +921,12 @@ func (t *AssetOper) genGrantBoxBody(param *xbase.GrantBoxParam) (string, error)
 	v.Set("consume_nonce", fmt.Sprintf("%d", consumeNonce))
 	v.Set("grant_nonce", fmt.Sprintf("%d", grantNonce))
 	v.Set("token", param.Token)
-	v.Set("user_addr", param.UAccount.Address)
+	v.Set("user_addr", param.UAccount.GetAddress())
 	v.Set("user_sign", uSign)
-	v.Set("user_pkey", param.UAccount.PublicKey)
-	v.Set("create_addr", param.CAccount.Address)
+	v.Set("user_pkey", param.UAccount.GetPublicKey())
+	v.Set("create_addr", param.CAccount.GetAddress())
 	v.Set("create_sign", cSign)
-	v.Set("create_pkey", param.CAccount.PublicKey)
+	v.Set("create_pkey", param.CAccount.GetPublicKey())
 	v.Set("user_id", fmt.Sprintf("%d", param.UserId))
 
 	body := v.Encode()
@@ -1175,41 +934,24 @@ func (t *AssetOper) genGrantBoxBody(param *xbase.GrantBoxParam) (string, error)
 }
 
 func (t *AssetOper) GrantBox(param *xbase.GrantBoxParam) (*xbase.GrantBoxResp, *xbase.RequestRes, error) {
+	return t.GrantBoxCtx(context.Background(), param)
+}
+
+func (t *AssetOper) GrantBoxCtx(ctx context.Context, param *xbase.GrantBoxParam) (*xbase.GrantBoxResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiGrantBox)
 	}
 
-	body, err := t.genGrantBoxBody(param)
+	body, err := t.genGrantBoxBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for grant box asset, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiGrantBox, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiGrantBox)
 	}
-
 	var resp xbase.GrantBoxResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiGrantBox, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1230,41 +972,24 @@ func (t *AssetOper) genSelMaterialBody(param *xbase.SelMaterialParam) (string, e
 }
 
 func (t *AssetOper) SelectMaterial(param *xbase.SelMaterialParam) (*xbase.SelMaterialResp, *xbase.RequestRes, error) {
+	return t.SelectMaterialCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SelectMaterialCtx(ctx context.Context, param *xbase.SelMaterialParam) (*xbase.SelMaterialResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiSelectMaterial)
 	}
 
 	body, err := t.genSelMaterialBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for select material, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiSelectMaterial, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiSelectMaterial)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.SelMaterialResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiSelectMaterial, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [select_cnt: %d] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		len(resp.List), resp.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1283,40 +1008,24 @@ func (t *AssetOper) genUpgradeAstBody(param *xbase.UpgradeAstParam) (string, err
 }
 
 func (t *AssetOper) UpgradeAst(param *xbase.UpgradeAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.UpgradeAstCtx(context.Background(), param)
+}
+
+func (t *AssetOper) UpgradeAstCtx(ctx context.Context, param *xbase.UpgradeAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUpgradeAst)
 	}
 
 	body, err := t.genUpgradeAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for upgrade asset, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiUpgradeAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUpgradeAst)
 	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiUpgradeAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]", res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1337,44 +1046,28 @@ func (t *AssetOper) genUpgradeSdsBody(param *xbase.UpgradeSdsParam) (string, err
 }
 
 func (t *AssetOper) UpgradeSds(param *xbase.UpgradeSdsParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.UpgradeSdsCtx(context.Background(), param)
+}
+
+func (t *AssetOper) UpgradeSdsCtx(ctx context.Context, param *xbase.UpgradeSdsParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUpgradeSds)
 	}
 
 	body, err := t.genUpgradeSdsBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for upgrade shard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiUpgradeSds, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUpgradeSds)
 	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiUpgradeSds, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ.[url: %s] [request_id: %s] [trace_id: %s]", res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
-func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, param *xbase.ComposeParam) (string, error) {
+func (t *AssetOper) genComposeShardBody(ctx context.Context, consumeList []*xbase.AssetShardPair, param *xbase.ComposeParam) (string, error) {
 	if len(consumeList) < 1 {
 		return "", xbase.ErrParamInvalid
 	}
@@ -1385,7 +1078,7 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 		//TODO need generate absolute uniq nonce
 		nonce := utils.GenNonce()
 		signMsg := fmt.Sprintf("%d%d", shard.AssetId, nonce)
-		sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+		sign, err := param.Account.Sign(ctx, []byte(signMsg))
 		if err != nil {
 			return "", xbase.ComErrAccountSignFailed
 		}
@@ -1405,7 +1098,7 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 	//build grant sign
 	nonce := utils.GenNonce()
 	signMsg := fmt.Sprintf("%d%d", param.AssetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1414,11 +1107,11 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	v.Set("strg_no", fmt.Sprintf("%d", param.StrgNo))
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
-	v.Set("addr", param.Account.Address)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("addr", param.Account.GetAddress())
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("sign", sign)
-	v.Set("uaddr", param.UAccount.Address)
-	v.Set("upkey", param.UAccount.PublicKey)
+	v.Set("uaddr", param.UAccount.GetAddress())
+	v.Set("upkey", param.UAccount.GetPublicKey())
 	v.Set("ast_list", string(jsAstList))
 	v.Set("token", param.Token)
 	body := v.Encode()
@@ -1426,41 +1119,24 @@ func (t *AssetOper) genComposeShardBody(consumeList []*xbase.AssetShardPair, par
 }
 
 func (t *AssetOper) ComposeShard(consumeList []*xbase.AssetShardPair, param *xbase.ComposeParam) (*xbase.ComposeResp, *xbase.RequestRes, error) {
+	return t.ComposeShardCtx(context.Background(), consumeList, param)
+}
+
+func (t *AssetOper) ComposeShardCtx(ctx context.Context, consumeList []*xbase.AssetShardPair, param *xbase.ComposeParam) (*xbase.ComposeResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiComposeShard)
 	}
 
-	body, err := t.genComposeShardBody(consumeList, param)
+	body, err := t.genComposeShardBody(ctx, consumeList, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for compose shard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiComposeShard)
 	}
-	res, err := t.Post(xbase.AssetApiComposeShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ComposeResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiComposeShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		resp.AssetId, resp.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1475,11 +1151,11 @@ func (t *AssetOper) ComposeShard(consumeList []*xbase.AssetShardPair, param *xba
 //			Pkey  	  string `json:"pkey"`
 //			Sign	  string `json:"sign"`
 //	}
-func (t *AssetOper) genLockShardBody(param *xbase.LockOrFreezeShardParam) (string, error) {
+func (t *AssetOper) genLockShardBody(ctx context.Context, param *xbase.LockOrFreezeShardParam) (string, error) {
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
@@ -1487,50 +1163,33 @@ func (t *AssetOper) genLockShardBody(param *xbase.LockOrFreezeShardParam) (strin
 	v.Set("asset_id", fmt.Sprintf("%d", assetId))
 	v.Set("shard_id", fmt.Sprintf("%d", param.ShardId))
 	v.Set("op_type", fmt.Sprintf("%d", param.OpType))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
 }
 
 func (t *AssetOper) LockShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.LockShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) LockShardCtx(ctx context.Context, param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiLockShard)
 	}
 
-	body, err := t.genLockShardBody(param)
+	body, err := t.genLockShardBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for locking shard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiLockShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiLockShard, err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiLockShard)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiLockShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1544,100 +1203,66 @@ func (t *AssetOper) LockShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseR
 //			Pkey  	  string `json:"pkey"`
 //			Sign	  string `json:"sign"`
 //	}
-func (t *AssetOper) genFreezeShardBody(param *xbase.LockOrFreezeShardParam) (string, error) {
+func (t *AssetOper) genFreezeShardBody(ctx context.Context, param *xbase.LockOrFreezeShardParam) (string, error) {
 	nonce := utils.GenNonce()
 	assetId := param.AssetId
 	signMsg := fmt.Sprintf("%d%d", assetId, nonce)
-	sign, err := auth.XassetSignECDSA(param.Account.PrivateKey, []byte(signMsg))
+	sign, err := param.Account.Sign(ctx, []byte(signMsg))
 	if err != nil {
 		return "", xbase.ComErrAccountSignFailed
 	}
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", assetId))
 	v.Set("shard_id", fmt.Sprintf("%d", param.ShardId))
-	v.Set("addr", param.Account.Address)
+	v.Set("addr", param.Account.GetAddress())
 	v.Set("sign", sign)
-	v.Set("pkey", param.Account.PublicKey)
+	v.Set("pkey", param.Account.GetPublicKey())
 	v.Set("nonce", fmt.Sprintf("%d", nonce))
 	body := v.Encode()
 	return body, nil
 }
 
 func (t *AssetOper) FreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.FreezeShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) FreezeShardCtx(ctx context.Context, param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiFreezeShard)
 	}
 
-	body, err := t.genFreezeShardBody(param)
+	body, err := t.genFreezeShardBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for freezing shard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.AssetApiFreezeShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiFreezeShard, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiFreezeShard)
 	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiFreezeShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) UnFreezeShard(param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.UnFreezeShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) UnFreezeShardCtx(ctx context.Context, param *xbase.LockOrFreezeShardParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUnfreezeShard)
 	}
 
-	body, err := t.genFreezeShardBody(param)
+	body, err := t.genFreezeShardBody(ctx, param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for unfreezing shard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.AssetApiUnfreezeShard)
 	}
-	res, err := t.Post(xbase.AssetApiUnfreezeShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.AssetApiUnfreezeShard, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.AssetApiUnfreezeShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1662,41 +1287,24 @@ func (t *AssetOper) genSceneListShardByAddrBody(param *xbase.SceneListShardByAdd
 
 // SceneListShardByAddr list shards under scene authorization.
 func (t *AssetOper) SceneListShardByAddr(param *xbase.SceneListShardByAddrParam) (*xbase.SceneListShardByAddrResp, *xbase.RequestRes, error) {
+	return t.SceneListShardByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SceneListShardByAddrCtx(ctx context.Context, param *xbase.SceneListShardByAddrParam) (*xbase.SceneListShardByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneListShardByAddr)
 	}
 
 	body, err := t.genSceneListShardByAddrBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for scene listshardbyaddr, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.SceneListShardByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SceneListShardByAddr, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneListShardByAddr)
 	}
-
 	var resp xbase.SceneListShardByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SceneListShardByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1720,41 +1328,24 @@ func (t *AssetOper) genSceneQueryShardBody(param *xbase.SceneQueryShardParam) (s
 
 // SceneQueryShard query shard under scene authorization.
 func (t *AssetOper) SceneQueryShard(param *xbase.SceneQueryShardParam) (*xbase.SceneQueryShardResp, *xbase.RequestRes, error) {
+	return t.SceneQueryShardCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SceneQueryShardCtx(ctx context.Context, param *xbase.SceneQueryShardParam) (*xbase.SceneQueryShardResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneQueryShard)
 	}
 
 	body, err := t.genSceneQueryShardBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for scene queryshard, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.SceneQueryShard, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SceneQueryShard, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneQueryShard)
 	}
-
 	var resp xbase.SceneQueryShardResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SceneQueryShard, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [asset_id: %d] [shard_id: %d] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, param.AssetId, param.ShardId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1785,37 +1376,20 @@ func (t *AssetOper) genSceneListDiffByAddrBody(param *xbase.SceneListDiffByAddrP
 }
 
 func (t *AssetOper) SceneListDiffByAddr(param *xbase.SceneListDiffByAddrParam) (*xbase.ListDiffByAddrResp, *xbase.RequestRes, error) {
+	return t.SceneListDiffByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SceneListDiffByAddrCtx(ctx context.Context, param *xbase.SceneListDiffByAddrParam) (*xbase.ListDiffByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneListDiffByAddr)
 	}
 	body, _ := t.genSceneListDiffByAddrBody(param)
 
-	res, err := t.Post(xbase.SceneListDiffByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListDiffByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SceneListDiffByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -1836,295 +1410,204 @@ func (t *AssetOper) genSceneHasAssetByAddrBody(param *xbase.SceneHasAssetByAddrP
 }
 
 func (t *AssetOper) SceneHasAssetByAddr(param *xbase.SceneHasAssetByAddrParam) (*xbase.SceneHasAssetByAddrResp, *xbase.RequestRes, error) {
+	return t.SceneHasAssetByAddrCtx(context.Background(), param)
+}
+
+func (t *AssetOper) SceneHasAssetByAddrCtx(ctx context.Context, param *xbase.SceneHasAssetByAddrParam) (*xbase.SceneHasAssetByAddrResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneHasAstByAddr)
 	}
 	body, _ := t.genSceneHasAssetByAddrBody(param)
 
-	res, err := t.Post(xbase.SceneHasAstByAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.SceneHasAstByAddr, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.SceneHasAssetByAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SceneHasAstByAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [addr: %s] [token: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.Addr, param.Token, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) SceneListAddr(uid string) (*xbase.SceneListAddrResp, *xbase.RequestRes, error) {
+	return t.SceneListAddrCtx(context.Background(), uid)
+}
+
+func (t *AssetOper) SceneListAddrCtx(ctx context.Context, uid string) (*xbase.SceneListAddrResp, *xbase.RequestRes, error) {
 	if err := xbase.UnionIdValid(uid); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneListAddr)
 	}
 	signedUnionId, err := t.aesEncodeStr(uid)
 	if err != nil {
 		t.Logger.Warn("encode union id fail, union id: %s", uid)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.SceneListAddr)
 	}
 	v := url.Values{}
 	v.Set("union_id", signedUnionId)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.SceneListAddr, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.SceneListAddr, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.SceneListAddrResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SceneListAddr, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		uid, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) BdBoxRegister(param *xbase.BdBoxRegisterParam) (*xbase.BdBoxRegisterResp, *xbase.RequestRes, error) {
+	return t.BdBoxRegisterCtx(context.Background(), param)
+}
+
+func (t *AssetOper) BdBoxRegisterCtx(ctx context.Context, param *xbase.BdBoxRegisterParam) (*xbase.BdBoxRegisterResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiRegister)
 	}
 	v := url.Values{}
 	signedOpenId, err := t.aesEncodeStr(param.OpenId)
 	if err != nil {
 		t.Logger.Warn("encode open id fail, open id: %s", param.OpenId)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiRegister)
 	}
 	signedAppKey, err := t.aesEncodeStr(param.AppKey)
 	if err != nil {
 		t.Logger.Warn("encode app key fail, app key: %s", param.AppKey)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiRegister)
 	}
 	v.Set("open_id", signedOpenId)
 	v.Set("app_key", signedAppKey)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.DidApiRegister, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiRegister, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BdBoxRegisterResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.DidApiRegister, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
 
 	decodeMnem, err := t.aesDecodeStr(resp.Mnemonic)
 	if err != nil {
 		t.Logger.Warn("get resp succ but cannot decode mnemonic. [url: %s] [request_id: %s] [trace_id: %s]",
 			res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
-		return &resp, res, err
+		return &resp, res, xbase.NewRespError(err, xbase.DidApiRegister, res, &resp.BaseResp)
 	}
 	resp.Mnemonic = decodeMnem
-
-	t.Logger.Trace("operate succ. [open_id: %s] [app_key: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.OpenId, param.AppKey, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) BdBoxBind(param *xbase.BdBoxBindParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.BdBoxBindCtx(context.Background(), param)
+}
+
+func (t *AssetOper) BdBoxBindCtx(ctx context.Context, param *xbase.BdBoxBindParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBind)
 	}
 	v := url.Values{}
 	signedOpenId, err := t.aesEncodeStr(param.OpenId)
 	if err != nil {
 		t.Logger.Warn("encode open id fail, open id: %s", param.OpenId)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBind)
 	}
 	signedAppKey, err := t.aesEncodeStr(param.AppKey)
 	if err != nil {
 		t.Logger.Warn("encode app key fail, app key: %s", param.AppKey)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBind)
 	}
 	signedMnem, err := t.aesEncodeStr(param.Mnemonic)
 	if err != nil {
-		t.Logger.Warn("encode mnemonic fail, mnemonic: %s", param.Mnemonic)
-		return nil, nil, err
+		t.Logger.Warn("encode mnemonic fail.[err:%v]", err)
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBind)
 	}
 	v.Set("open_id", signedOpenId)
 	v.Set("app_key", signedAppKey)
 	v.Set("mnemonic", signedMnem)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.DidApiBind, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiBind, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.DidApiBind, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [open_id: %s] [app_key: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.OpenId, param.AppKey, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) BindByUnionId(param *xbase.BindByUnionIdParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.BindByUnionIdCtx(context.Background(), param)
+}
+
+func (t *AssetOper) BindByUnionIdCtx(ctx context.Context, param *xbase.BindByUnionIdParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBindByUid)
 	}
 
 	signedUnionId, err := t.aesEncodeStr(param.UnionId)
 	if err != nil {
 		t.Logger.Warn("encode union id fail, union id: %s", param.UnionId)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBindByUid)
 	}
 	signedMnem, err := t.aesEncodeStr(param.Mnemonic)
 	if err != nil {
-		t.Logger.Warn("encode mnemonic fail, mnemonic: %s", param.Mnemonic)
-		return nil, nil, err
+		t.Logger.Warn("encode mnemonic fail.[err:%v]", err)
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiBindByUid)
 	}
 	v := url.Values{}
 	v.Set("union_id", signedUnionId)
 	v.Set("mnemonic", signedMnem)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.DidApiBindByUid, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiBindByUid, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.DidApiBindByUid, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.UnionId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) GetAddrByUnionId(uid string) (*xbase.GetAddrByUnionIdResp, *xbase.RequestRes, error) {
+	return t.GetAddrByUnionIdCtx(context.Background(), uid)
+}
+
+func (t *AssetOper) GetAddrByUnionIdCtx(ctx context.Context, uid string) (*xbase.GetAddrByUnionIdResp, *xbase.RequestRes, error) {
 	if err := xbase.UnionIdValid(uid); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiGetAddrByUid)
 	}
 	signedUnionId, err := t.aesEncodeStr(uid)
 	if err != nil {
 		t.Logger.Warn("encode union id fail, union id: %s", uid)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.DidApiGetAddrByUid)
 	}
 	v := url.Values{}
 	v.Set("union_id", signedUnionId)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.DidApiGetAddrByUid, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. url: %s, err: %v", xbase.DidApiGetAddrByUid, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post req resp not 200.[http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.GetAddrByUnionIdResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.DidApiGetAddrByUid, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed.err:%v [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			err, res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [union_id: %s] [url: %s] [request_id: %s] [trace_id: %s]",
-		uid, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) aesEncodeStr(str string) (string, error) {
-	return utils.AesEncode(str, t.Cfg.Credentials.SecretAccessKey)
+	cred, err := t.GetCredentials()
+	if err != nil {
+		return "", err
+	}
+	return utils.AesEncode(str, cred.SecretAccessKey)
 }
 
 func (t *AssetOper) aesDecodeStr(str string) (string, error) {
-	return utils.AesDecode(str, t.Cfg.Credentials.SecretAccessKey)
+	cred, err := t.GetCredentials()
+	if err != nil {
+		return "", err
+	}
+	return utils.AesDecode(str, cred.SecretAccessKey)
 }
 
 func (t *AssetOper) VilgText2Img(param *xbase.VilgText2ImgParam) (*xbase.VilgText2ImgResp, *xbase.RequestRes, error) {
+	return t.VilgText2ImgCtx(context.Background(), param)
+}
+
+func (t *AssetOper) VilgText2ImgCtx(ctx context.Context, param *xbase.VilgText2ImgParam) (*xbase.VilgText2ImgResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.VilgApiText2Img)
 	}
 
 	v := url.Values{}
@@ -2134,99 +1617,44 @@ func (t *AssetOper) VilgText2Img(param *xbase.VilgText2ImgParam) (*xbase.VilgTex
 	v.Set("extend", param.Extend)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.VilgApiText2Img, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.VilgText2ImgResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.VilgApiText2Img, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [taskId:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.TaskId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) VilgGetImg(taskId int64) (*xbase.VilgGetImgResp, *xbase.RequestRes, error) {
+	return t.VilgGetImgCtx(context.Background(), taskId)
+}
+
+func (t *AssetOper) VilgGetImgCtx(ctx context.Context, taskId int64) (*xbase.VilgGetImgResp, *xbase.RequestRes, error) {
 	if taskId <= 0 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.VilgApiGetImg)
 	}
 
 	v := url.Values{}
 	v.Set("task_id", strconv.FormatInt(taskId, 10))
 	body := v.Encode()
 
-	res, err := t.Post(xbase.VilgApiGetImg, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.VilgGetImgResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.VilgApiGetImg, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
-	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
+		return nil, res, err
 	}
-
-	t.Logger.Trace("operate succ. [status:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Task.Status, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *AssetOper) VilgBalance() (*xbase.VilgBalanceResp, *xbase.RequestRes, error) {
-	res, err := t.Post(xbase.VilgApiBalance, "")
-	if err != nil {
-		t.Logger.Warn("post request xasset failed. err: %v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
+	return t.VilgBalanceCtx(context.Background())
+}
 
+func (t *AssetOper) VilgBalanceCtx(ctx context.Context) (*xbase.VilgBalanceResp, *xbase.RequestRes, error) {
 	var resp xbase.VilgBalanceResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.VilgApiBalance, "", &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [balance:%+v] [url:%s] [request_id:%s] [trace_id:%s]",
-		resp.Balance, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
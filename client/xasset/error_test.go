@@ -0,0 +1,119 @@
+package xasset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+func newStubAssetOper(t *testing.T, code int, body string) (*AssetOper, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(base.TraceIdHeader, "trace-123")
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cli, err := NewAssetOperCli(cfg, &base.TestLogger{})
+	if err != nil {
+		srv.Close()
+		t.Fatalf("new asset client failed.err:%v", err)
+	}
+	return cli, srv.Close
+}
+
+func TestXassetErrorErrno(t *testing.T) {
+	cli, closeFn := newStubAssetOper(t, 200, `{"request_id":"req-1","errno":10002,"errmsg":"asset not exist"}`)
+	defer closeFn()
+
+	_, res, err := cli.QueryAsset(&base.QueryAssetParam{AssetId: 123})
+	if !errors.Is(err, base.ComErrServRespErrnoErr) {
+		t.Fatalf("errors.Is failed.err:%v", err)
+	}
+	if res == nil {
+		t.Fatalf("request res should be returned")
+	}
+	var xe *base.XassetError
+	if !errors.As(err, &xe) {
+		t.Fatalf("errors.As failed.err:%v", err)
+	}
+	if xe.HttpCode != 200 || xe.Errno != 10002 || xe.Errmsg != "asset not exist" ||
+		xe.RequestId != "req-1" || xe.TraceId != "trace-123" || xe.Uri != base.AssetApiQueryAsset {
+		t.Errorf("xasset error fields not match.err:%+v", xe)
+	}
+}
+
+func TestXassetErrorHttpCode(t *testing.T) {
+	cli, closeFn := newStubAssetOper(t, 500, `internal error`)
+	defer closeFn()
+
+	_, res, err := cli.QueryAsset(&base.QueryAssetParam{AssetId: 123})
+	if !errors.Is(err, base.ComErrRespCodeErr) {
+		t.Fatalf("errors.Is failed.err:%v", err)
+	}
+	var xe *base.XassetError
+	if !errors.As(err, &xe) || xe.HttpCode != 500 || xe.TraceId != "trace-123" || res == nil {
+		t.Errorf("xasset error fields not match.err:%v", err)
+	}
+}
+
+func TestXassetErrorParam(t *testing.T) {
+	cli, closeFn := newStubAssetOper(t, 200, `{"errno":0}`)
+	defer closeFn()
+
+	_, _, err := cli.QueryAsset(&base.QueryAssetParam{})
+	if !errors.Is(err, base.ErrAssetInvalid) {
+		t.Fatalf("errors.Is failed.err:%v", err)
+	}
+	var xe *base.XassetError
+	if !errors.As(err, &xe) || xe.Uri != base.AssetApiQueryAsset || xe.HttpCode != 0 {
+		t.Errorf("xasset error fields not match.err:%v", err)
+	}
+}
+
+func TestAssetOperConcurrent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Header.Get("X-Asset-Id") != r.PostForm.Get("asset_id") {
+			w.WriteHeader(400)
+			return
+		}
+		w.Write([]byte(`{"errno":0,"meta":{"asset_id":` + r.PostForm.Get("asset_id") + `}}`))
+	}))
+	defer srv.Close()
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cli, err := NewAssetOperCli(cfg, &base.TestLogger{})
+	if err != nil {
+		t.Fatalf("new asset client failed.err:%v", err)
+	}
+	// 初始化后修改配置不影响客户端
+	cfg.Endpoint = "http://127.0.0.1:1"
+
+	var wg sync.WaitGroup
+	var failed int32
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			ctx := base.ContextWithOptions(context.Background(),
+				base.WithHeader("X-Asset-Id", strconv.FormatInt(id, 10)), base.WithTimeout(5*time.Second))
+			resp, _, err := cli.QueryAssetCtx(ctx, &base.QueryAssetParam{AssetId: id})
+			if err != nil || resp.Meta == nil || resp.Meta.AssetId != id {
+				atomic.AddInt32(&failed, 1)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+	if failed != 0 {
+		t.Errorf("concurrent query asset failed.[failed:%d]", failed)
+	}
+}
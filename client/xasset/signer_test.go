@@ -0,0 +1,77 @@
+package xasset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+)
+
+// stubSigner 模拟密钥服务中的账户
+type stubSigner struct {
+	err error
+}
+
+func (t *stubSigner) GetAddress() string   { return "stub-addr" }
+func (t *stubSigner) GetPublicKey() string { return "stub-pkey" }
+func (t *stubSigner) Sign(ctx context.Context, msg []byte) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if t.err != nil {
+		return "", t.err
+	}
+	return "stub-sign:" + string(msg), nil
+}
+
+func TestAssetOperSigner(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		w.Write([]byte(`{"request_id":"1","errno":0,"asset_id":1,"shard_id":2}`))
+	}))
+	defer srv.Close()
+	cfg := base.TestGetXassetConfig()
+	cfg.Endpoint = srv.URL
+	cli, err := NewAssetOperCli(cfg, &base.TestLogger{})
+	if err != nil {
+		t.Fatalf("new asset client failed.err:%v", err)
+	}
+
+	param := &base.GrantAssetParam{
+		Account: &stubSigner{},
+		AssetId: 1,
+		ShardId: 2,
+		Addr:    "stub-addr",
+		ToAddr:  "to-addr",
+	}
+	if _, _, err := cli.GrantAsset(param); err != nil {
+		t.Fatalf("grant asset failed.err:%v", err)
+	}
+	if form.Get("pkey") != "stub-pkey" || form.Get("sign") != "stub-sign:1"+form.Get("nonce") {
+		t.Errorf("request should be signed by signer.form:%v", form)
+	}
+
+	// 签名使用接口调用的ctx
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := cli.GrantAssetCtx(ctx, param); !errors.Is(err, base.ComErrAccountSignFailed) {
+		t.Errorf("sign should use call ctx.err:%v", err)
+	}
+
+	param.Account = &stubSigner{err: errors.New("kms unavailable")}
+	if _, _, err := cli.GrantAsset(param); !errors.Is(err, base.ComErrAccountSignFailed) {
+		t.Errorf("signer error should be returned.err:%v", err)
+	}
+	var acc *auth.Account
+	param.Account = acc
+	if _, _, err := cli.GrantAsset(param); !errors.Is(err, base.ErrNilPointer) {
+		t.Errorf("nil account should be rejected.err:%v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package xstore
+
+import (
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/client/base"
+	"github.com/xuperchain/xasset-sdk-go/internal/testutil"
+)
+
+func TestRedactLogs(t *testing.T) {
+	testutil.CheckClientRedact(t, func(endpoint string, logger *testutil.CaptureLogger) (interface{}, interface{}, error) {
+		cfg := base.TestGetXassetConfig()
+		cfg.Endpoint = endpoint
+		cfg.SetCredentials(1, "ak", testutil.RedactSK)
+		handle, err := NewXstoreOper(cfg, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return handle, &handle.XassetBaseClient, nil
+	})
+}
@@ -1,8 +1,8 @@
 package xstore
 
 import (
+	"context"
 	"crypto/md5"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -42,80 +42,46 @@ func (t *StoreOper) genCreateOrAlterStoreBody(param *xbase.CreateOrAlterStorePar
 }
 
 func (t *StoreOper) CreateStore(param *xbase.CreateOrAlterStoreParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.CreateStoreCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CreateStoreCtx(ctx context.Context, param *xbase.CreateOrAlterStoreParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.CreateValid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCreate)
 	}
 
 	body, err := t.genCreateOrAlterStoreBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for create store, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.StoreApiCreate, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCreate)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiCreate, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) AlterStore(param *xbase.CreateOrAlterStoreParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.AlterStoreCtx(context.Background(), param)
+}
+
+func (t *StoreOper) AlterStoreCtx(ctx context.Context, param *xbase.CreateOrAlterStoreParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if param.StoreId < 1 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiAlter)
 	}
 
 	body, err := t.genCreateOrAlterStoreBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for alter store, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.StoreApiAlter, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiAlter)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiAlter, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -127,71 +93,37 @@ func (t *StoreOper) genQueryStoreBody(param *xbase.BaseStoreParam) (string, erro
 }
 
 func (t *StoreOper) QueryStore(param *xbase.BaseStoreParam) (*xbase.QueryStoreResp, *xbase.RequestRes, error) {
+	return t.QueryStoreCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryStoreCtx(ctx context.Context, param *xbase.BaseStoreParam) (*xbase.QueryStoreResp, *xbase.RequestRes, error) {
 	if param.StoreId < 1 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiQuery)
 	}
 
 	body, err := t.genQueryStoreBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for query store, err: %v, param: %+v", err, *param)
-		return nil, nil, err
-	}
-	res, err := t.Post(xbase.StoreApiQuery, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiQuery)
 	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryStoreResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiQuery, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) ListStore() (*xbase.ListStoreResp, *xbase.RequestRes, error) {
-	res, err := t.Post(xbase.StoreApiList, "")
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
+	return t.ListStoreCtx(context.Background())
+}
 
+func (t *StoreOper) ListStoreCtx(ctx context.Context) (*xbase.ListStoreResp, *xbase.RequestRes, error) {
 	var resp xbase.ListStoreResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiList, "", &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [url: %s] [request_id: %s] [trace_id: %s]",
-		res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -215,80 +147,46 @@ func (t *StoreOper) genCreateOrAlterActBody(param *xbase.CreateOrAlterActParam)
 }
 
 func (t *StoreOper) CreateAct(param *xbase.CreateOrAlterActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.CreateActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CreateActCtx(ctx context.Context, param *xbase.CreateOrAlterActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.CreateValid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCreateAct)
 	}
 
 	body, err := t.genCreateOrAlterActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for create act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCreateAct)
 	}
-	res, err := t.Post(xbase.StoreApiCreateAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiCreateAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) AlterAct(param *xbase.CreateOrAlterActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.AlterActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) AlterActCtx(ctx context.Context, param *xbase.CreateOrAlterActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if param.ActId < 1 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiAlterAct)
 	}
 
 	body, err := t.genCreateOrAlterActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for alter act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiAlterAct)
 	}
-	res, err := t.Post(xbase.StoreApiAlterAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiAlterAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -301,80 +199,46 @@ func (t *StoreOper) genBaseActBody(param *xbase.BaseActParam) (string, error) {
 }
 
 func (t *StoreOper) RemoveAct(param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.RemoveActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) RemoveActCtx(ctx context.Context, param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiRemoveAct)
 	}
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for remove act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiRemoveAct)
 	}
-	res, err := t.Post(xbase.StoreApiRemoveAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiRemoveAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) QueryAct(param *xbase.BaseActParam) (*xbase.QueryActResp, *xbase.RequestRes, error) {
+	return t.QueryActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryActCtx(ctx context.Context, param *xbase.BaseActParam) (*xbase.QueryActResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiQueryAct)
 	}
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for query act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiQueryAct)
 	}
-	res, err := t.Post(xbase.StoreApiQueryAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryActResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiQueryAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -388,80 +252,46 @@ func (t *StoreOper) genListActBody(param *xbase.ListActParam) (string, error) {
 }
 
 func (t *StoreOper) ListAct(param *xbase.ListActParam) (*xbase.ListActResp, *xbase.RequestRes, error) {
+	return t.ListActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) ListActCtx(ctx context.Context, param *xbase.ListActParam) (*xbase.ListActResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiListAct)
 	}
 
 	body, err := t.genListActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for list act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiListAct)
 	}
-	res, err := t.Post(xbase.StoreApiListAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListActResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiListAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [store_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.StoreId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) PubAct(param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.PubActCtx(context.Background(), param)
+}
+
+func (t *StoreOper) PubActCtx(ctx context.Context, param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiPubAct)
 	}
 
 	body, err := t.genBaseActBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for pub act, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiPubAct)
 	}
-	res, err := t.Post(xbase.StoreApiPubAct, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiPubAct, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -486,80 +316,46 @@ func (t *StoreOper) genBindOrAlterAstBody(param *xbase.BindOrAlterAstParam) (str
 }
 
 func (t *StoreOper) BindAst(param *xbase.BindOrAlterAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.BindAstCtx(context.Background(), param)
+}
+
+func (t *StoreOper) BindAstCtx(ctx context.Context, param *xbase.BindOrAlterAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.CreateValid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiBindAst)
 	}
 
 	body, err := t.genBindOrAlterAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for bind ast, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiBindAst)
 	}
-	res, err := t.Post(xbase.StoreApiBindAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiBindAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) AlterAst(param *xbase.BindOrAlterAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.AlterAstCtx(context.Background(), param)
+}
+
+func (t *StoreOper) AlterAstCtx(ctx context.Context, param *xbase.BindOrAlterAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.AlterValid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiAlterAst)
 	}
 
 	body, err := t.genBindOrAlterAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for alter ast, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiAlterAst)
 	}
-	res, err := t.Post(xbase.StoreApiAlterAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiAlterAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -572,41 +368,24 @@ func (t *StoreOper) genCancelAstBody(param *xbase.BaseAstParam) (string, error)
 }
 
 func (t *StoreOper) CancelAst(param *xbase.BaseAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.CancelAstCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CancelAstCtx(ctx context.Context, param *xbase.BaseAstParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCancelAst)
 	}
 
 	body, err := t.genCancelAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for cancel ast, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCancelAst)
 	}
-	res, err := t.Post(xbase.StoreApiCancelAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiCancelAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -619,41 +398,24 @@ func (t *StoreOper) genCancelAstByActIdBody(param *xbase.BaseActParam) (string,
 }
 
 func (t *StoreOper) CancelAstByActId(param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.CancelAstByActIdCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CancelAstByActIdCtx(ctx context.Context, param *xbase.BaseActParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCancelAstByActId)
 	}
 
 	body, err := t.genCancelAstByActIdBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for cancel ast by act_id, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiCancelAstByActId)
 	}
-	res, err := t.Post(xbase.StoreApiCancelAstByActId, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiCancelAstByActId, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -666,41 +428,24 @@ func (t *StoreOper) genQueryActAstBody(param *xbase.BaseAstParam) (string, error
 }
 
 func (t *StoreOper) QueryActAst(param *xbase.BaseAstParam) (*xbase.QueryActAstResp, *xbase.RequestRes, error) {
+	return t.QueryActAstCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryActAstCtx(ctx context.Context, param *xbase.BaseAstParam) (*xbase.QueryActAstResp, *xbase.RequestRes, error) {
 	if param.ActId < 1 || param.AssetId < 1 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiQueryAst)
 	}
 
 	body, err := t.genQueryActAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for query act ast, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiQueryAst)
 	}
-	res, err := t.Post(xbase.StoreApiQueryAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryActAstResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiQueryAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [asset_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.AssetId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
@@ -712,71 +457,58 @@ func (t *StoreOper) genListActAstBody(param *xbase.BaseActParam) (string, error)
 }
 
 func (t *StoreOper) ListActAst(param *xbase.BaseActParam) (*xbase.ListActAstResp, *xbase.RequestRes, error) {
+	return t.ListActAstCtx(context.Background(), param)
+}
+
+func (t *StoreOper) ListActAstCtx(ctx context.Context, param *xbase.BaseActParam) (*xbase.ListActAstResp, *xbase.RequestRes, error) {
 	if param.ActId < 1 {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.StoreApiListAst)
 	}
 
 	body, err := t.genListActAstBody(param)
 	if err != nil {
 		t.Logger.Warn("fail to generate value for list act ast, err: %v, param: %+v", err, *param)
-		return nil, nil, err
+		return nil, nil, xbase.NewXassetError(err, xbase.StoreApiListAst)
 	}
-	res, err := t.Post(xbase.StoreApiListAst, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed.err:%v", err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.ListActAstResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.StoreApiListAst, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [act_id: %v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param.ActId, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // CreateOrder creates orders.
 func (t *StoreOper) CreateOrder(param *xbase.HubCreateOrderParam, uid int64, auth string) (*xbase.HubCreateResp, *xbase.RequestRes, error) {
+	return t.CreateOrderCtx(context.Background(), param, uid, auth)
+}
+
+func (t *StoreOper) CreateOrderCtx(ctx context.Context, param *xbase.HubCreateOrderParam, uid int64, auth string) (*xbase.HubCreateResp, *xbase.RequestRes, error) {
 	var err error
 	if err = param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubCreateOrder)
 	}
 	// 使用百度收银台H5支付组件请务必携带鉴权串
 	if param.Code == xbase.CodeBaiduH5 && auth == "" {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubCreateOrder)
 	}
 	// 使用百度收银台请务必携带uk
 	if _, ok := xbase.BaiduCashierCode[param.Code]; ok {
 		if uid <= 0 {
-			return nil, nil, xbase.ErrParamInvalid
+			return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubCreateOrder)
 		}
 	}
 	var secretAuth, uk string
 	if auth != "" {
 		secretAuth, err = t.GenSecretData(auth)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, xbase.NewXassetError(err, xbase.HubCreateOrder)
 		}
 	}
 	if uid > 0 {
 		uk, err = t.GenSecretData(fmt.Sprintf("%d", uid))
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, xbase.NewXassetError(err, xbase.HubCreateOrder)
 		}
 	}
 
@@ -801,47 +533,30 @@ func (t *StoreOper) CreateOrder(param *xbase.HubCreateOrderParam, uid int64, aut
 	v.Set("buy_count", fmt.Sprintf("%d", param.BuyCount))
 	body := v.Encode()
 
-	res, err := t.Post(xbase.HubCreateOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubCreateOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.HubCreateResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubCreateOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // ConfirmOrder confirms orders.
 func (t *StoreOper) ConfirmOrder(param *xbase.HubConfirmH5OrderParam, auth string) (*xbase.HubCreateResp, *xbase.RequestRes, error) {
+	return t.ConfirmOrderCtx(context.Background(), param, auth)
+}
+
+func (t *StoreOper) ConfirmOrderCtx(ctx context.Context, param *xbase.HubConfirmH5OrderParam, auth string) (*xbase.HubCreateResp, *xbase.RequestRes, error) {
 	var err error
 	if err = param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubConfirmOrder)
 	}
 	// 使用百度收银台H5支付时，请提供鉴权串
 	var secretAuth string
 	if auth != "" {
 		secretAuth, err = t.GenSecretData(auth)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, xbase.NewXassetError(err, xbase.HubConfirmOrder)
 		}
 	}
 	v := url.Values{}
@@ -852,76 +567,42 @@ func (t *StoreOper) ConfirmOrder(param *xbase.HubConfirmH5OrderParam, auth strin
 	v.Set("creator_details", param.Details)
 	v.Set("signed_auth", secretAuth)
 	body := v.Encode()
-	res, err := t.Post(xbase.HubConfirmOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubConfirmOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.HubCreateResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubConfirmOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // QueryOrderDetail gets order info.
 func (t *StoreOper) QueryOrderDetail(param *xbase.HubOrderDetailParam) (*xbase.HubOrderDetailResp, *xbase.RequestRes, error) {
+	return t.QueryOrderDetailCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryOrderDetailCtx(ctx context.Context, param *xbase.HubOrderDetailParam) (*xbase.HubOrderDetailResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubDetailOrder)
 	}
 	v := url.Values{}
 	v.Set("oid", fmt.Sprintf("%d", param.Oid))
 	body := v.Encode()
-	res, err := t.Post(xbase.HubDetailOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubDetailOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.HubOrderDetailResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubDetailOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // EditOrder edits order info.
 func (t *StoreOper) EditOrder(param *xbase.HubEditOrderParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.EditOrderCtx(context.Background(), param)
+}
+
+func (t *StoreOper) EditOrderCtx(ctx context.Context, param *xbase.HubEditOrderParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubEditOrder)
 	}
 	v := url.Values{}
 	v.Set("oid", fmt.Sprintf("%d", param.Oid))
@@ -934,39 +615,22 @@ func (t *StoreOper) EditOrder(param *xbase.HubEditOrderParam) (*xbase.BaseResp,
 	v.Set("close_reason", param.CloseReason)
 	body := v.Encode()
 
-	res, err := t.Post(xbase.HubEditOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubEditOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubEditOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // QueryOrderList gets order list by address.
 func (t *StoreOper) QueryOrderList(param *xbase.HubListOrderParam) (*xbase.HubListOrderResp, *xbase.RequestRes, error) {
+	return t.QueryOrderListCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryOrderListCtx(ctx context.Context, param *xbase.HubListOrderParam) (*xbase.HubListOrderResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubListOrder)
 	}
 	v := url.Values{}
 	v.Set("address", param.Addr)
@@ -978,39 +642,22 @@ func (t *StoreOper) QueryOrderList(param *xbase.HubListOrderParam) (*xbase.HubLi
 	v.Set("monotonicity", fmt.Sprintf("%d", param.Mono))
 
 	body := v.Encode()
-	res, err := t.Post(xbase.HubListOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubListOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.HubListOrderResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubListOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // QueryOrderPage gets order pages by address.
 func (t *StoreOper) QueryOrderPage(param *xbase.HubOrderPageParam) (*xbase.HubOrderPageResp, *xbase.RequestRes, error) {
+	return t.QueryOrderPageCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryOrderPageCtx(ctx context.Context, param *xbase.HubOrderPageParam) (*xbase.HubOrderPageResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.HubListOrderPage)
 	}
 	v := url.Values{}
 	v.Set("address", param.Addr)
@@ -1021,78 +668,44 @@ func (t *StoreOper) QueryOrderPage(param *xbase.HubOrderPageParam) (*xbase.HubOr
 	v.Set("time_end", fmt.Sprintf("%d", param.TimeEnd))
 
 	body := v.Encode()
-	res, err := t.Post(xbase.HubListOrderPage, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.HubListOrderPage, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.HubOrderPageResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.HubListOrderPage, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // CountOrder count valid orders.
 func (t *StoreOper) CountOrder(param *xbase.CountOrderParam) (*xbase.CountOrderResp, *xbase.RequestRes, error) {
+	return t.CountOrderCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CountOrderCtx(ctx context.Context, param *xbase.CountOrderParam) (*xbase.CountOrderResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.CountOrder)
 	}
 	v := url.Values{}
 	v.Set("asset_id", fmt.Sprintf("%d", param.AssetId))
 	v.Set("status", fmt.Sprintf("%d", param.Status))
 
 	body := v.Encode()
-	res, err := t.Post(xbase.CountOrder, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.CountOrder, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.CountOrderResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.CountOrder, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // SumOrderPrice sum valid orders price.
 func (t *StoreOper) SumOrderPrice(param *xbase.SumOrderPriceParam) (*xbase.SumOrderPriceResp, *xbase.RequestRes, error) {
+	return t.SumOrderPriceCtx(context.Background(), param)
+}
+
+func (t *StoreOper) SumOrderPriceCtx(ctx context.Context, param *xbase.SumOrderPriceParam) (*xbase.SumOrderPriceResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.SumOrderPrice)
 	}
 	v := url.Values{}
 	v.Set("status", fmt.Sprintf("%d", param.Status))
@@ -1104,37 +717,20 @@ func (t *StoreOper) SumOrderPrice(param *xbase.SumOrderPriceParam) (*xbase.SumOr
 	}
 
 	body := v.Encode()
-	res, err := t.Post(xbase.SumOrderPrice, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.SumOrderPriceResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SumOrderPrice, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 func (t *StoreOper) GenSecretData(data string) (string, error) {
-	input := fmt.Sprintf("%d_%s_%s", t.Cfg.Credentials.AppId, t.Cfg.Credentials.AccessKeyId, t.Cfg.Credentials.SecretAccessKey)
+	cred, err := t.GetCredentials()
+	if err != nil {
+		return "", err
+	}
+	input := fmt.Sprintf("%d_%s_%s", cred.AppId, cred.AccessKeyId, cred.SecretAccessKey)
 	h := md5.New()
 	io.WriteString(h, input)
 	digest := h.Sum(nil)
@@ -1144,46 +740,33 @@ func (t *StoreOper) GenSecretData(data string) (string, error) {
 
 // CheckRefund check order refundable
 func (t *StoreOper) CheckRefund(param *xbase.CheckRefundParam) (*xbase.CheckRefundResp, *xbase.RequestRes, error) {
+	return t.CheckRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CheckRefundCtx(ctx context.Context, param *xbase.CheckRefundParam) (*xbase.CheckRefundResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.CheckRefund)
 	}
 	v := url.Values{}
 	v.Set("oid", fmt.Sprintf("%d", param.Oid))
 
 	body := v.Encode()
-	res, err := t.Post(xbase.CheckRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.CheckRefundResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.CheckRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // CreateRefund create a refund order
 func (t *StoreOper) CreateRefund(param *xbase.CreateRefundParam) (*xbase.CreateRefundResp, *xbase.RequestRes, error) {
+	return t.CreateRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CreateRefundCtx(ctx context.Context, param *xbase.CreateRefundParam) (*xbase.CreateRefundResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.CreateRefund)
 	}
 	v := url.Values{}
 	v.Set("oid", fmt.Sprintf("%d", param.Oid))
@@ -1191,40 +774,23 @@ func (t *StoreOper) CreateRefund(param *xbase.CreateRefundParam) (*xbase.CreateR
 	v.Set("reason", param.Reason)
 
 	body := v.Encode()
-	res, err := t.Post(xbase.CreateRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.CreateRefundResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.CreateRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // CancelRefund cancel a refund order
 func (t *StoreOper) CancelRefund(param *xbase.CancelRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.CancelRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) CancelRefundCtx(ctx context.Context, param *xbase.CancelRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.CancelRefund)
 	}
 	v := url.Values{}
 
@@ -1232,39 +798,22 @@ func (t *StoreOper) CancelRefund(param *xbase.CancelRefundParam) (*xbase.BaseRes
 	v.Set("address", param.Address)
 
 	body := v.Encode()
-	res, err := t.Post(xbase.CancelRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.CancelRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // ConfirmRefund pass a refund order
 func (t *StoreOper) ConfirmRefund(param *xbase.ConfirmRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.ConfirmRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) ConfirmRefundCtx(ctx context.Context, param *xbase.ConfirmRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.ConfirmRefund)
 	}
 	v := url.Values{}
 	v.Set("rid", fmt.Sprintf("%d", param.Rid))
@@ -1272,39 +821,22 @@ func (t *StoreOper) ConfirmRefund(param *xbase.ConfirmRefundParam) (*xbase.BaseR
 	v.Set("operator", param.Operator)
 
 	body := v.Encode()
-	res, err := t.Post(xbase.ConfirmRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.ConfirmRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // RefuseRefund refuse a refund order
 func (t *StoreOper) RefuseRefund(param *xbase.RefuseRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
+	return t.RefuseRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) RefuseRefundCtx(ctx context.Context, param *xbase.RefuseRefundParam) (*xbase.BaseResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.RefuseRefund)
 	}
 	v := url.Values{}
 	v.Set("rid", fmt.Sprintf("%d", param.Rid))
@@ -1312,77 +844,43 @@ func (t *StoreOper) RefuseRefund(param *xbase.RefuseRefundParam) (*xbase.BaseRes
 	v.Set("operator", param.Operator)
 
 	body := v.Encode()
-	res, err := t.Post(xbase.RefuseRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumOrderPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.BaseResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.RefuseRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // QueryRefund query refundinfo by refund id.
 func (t *StoreOper) QueryRefund(param *xbase.QueryRefundParam) (*xbase.QueryRefundResp, *xbase.RequestRes, error) {
+	return t.QueryRefundCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryRefundCtx(ctx context.Context, param *xbase.QueryRefundParam) (*xbase.QueryRefundResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.QueryRefund)
 	}
 	v := url.Values{}
 	v.Set("rid", fmt.Sprintf("%d", param.Rid))
 
 	body := v.Encode()
-	res, err := t.Post(xbase.QueryRefund, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.QueryRefund, err)
-			return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryRefundResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.QueryRefund, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // QueryRefundPage query refundinfo return by page.
 func (t *StoreOper) QueryRefundPage(param *xbase.QueryRefundPageParam) (*xbase.QueryRefundPageResp, *xbase.RequestRes, error) {
+	return t.QueryRefundPageCtx(context.Background(), param)
+}
+
+func (t *StoreOper) QueryRefundPageCtx(ctx context.Context, param *xbase.QueryRefundPageParam) (*xbase.QueryRefundPageResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.QueryRefundPage)
 	}
 	v := url.Values{}
 	v.Set("address", param.Address)
@@ -1396,39 +894,22 @@ func (t *StoreOper) QueryRefundPage(param *xbase.QueryRefundPageParam) (*xbase.Q
 	}
 
 	body := v.Encode()
-	res, err := t.Post(xbase.QueryRefundPage, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.QueryRefundPage, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.QueryRefundPageResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.QueryRefundPage, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
 
 // SumRefundPrice return total count and price sum of refunds
 func (t *StoreOper) SumRefundPrice(param *xbase.SumRefundPriceParam) (*xbase.SumRefundPriceResp, *xbase.RequestRes, error) {
+	return t.SumRefundPriceCtx(context.Background(), param)
+}
+
+func (t *StoreOper) SumRefundPriceCtx(ctx context.Context, param *xbase.SumRefundPriceParam) (*xbase.SumRefundPriceResp, *xbase.RequestRes, error) {
 	if err := param.Valid(); err != nil {
-		return nil, nil, xbase.ErrParamInvalid
+		return nil, nil, xbase.NewXassetError(xbase.ErrParamInvalid, xbase.SumRefundPrice)
 	}
 	v := url.Values{}
 	v.Set("store_id", fmt.Sprintf("%d", param.StoreId))
@@ -1437,31 +918,10 @@ func (t *StoreOper) SumRefundPrice(param *xbase.SumRefundPriceParam) (*xbase.Sum
 	}
 
 	body := v.Encode()
-	res, err := t.Post(xbase.SumRefundPrice, body)
-	if err != nil {
-		t.Logger.Warn("post request xasset failed, uri: %s, err: %v", xbase.SumRefundPrice, err)
-		return nil, nil, xbase.ComErrRequsetFailed
-	}
-	if res.HttpCode != 200 {
-		t.Logger.Warn("post request response is not 200. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, nil, xbase.ComErrRespCodeErr
-	}
-
 	var resp xbase.SumRefundPriceResp
-	err = json.Unmarshal([]byte(res.Body), &resp)
+	res, err := t.Call(ctx, xbase.SumRefundPrice, body, &resp)
 	if err != nil {
-		t.Logger.Warn("unmarshal body failed. [http_code: %d] [url: %s] [body: %s] [trace_id: %s]",
-			res.HttpCode, res.ReqUrl, res.Body, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrUnmarshalBodyFailed
+		return nil, res, err
 	}
-	if resp.Errno != xbase.XassetErrNoSucc {
-		t.Logger.Warn("get resp failed. [url: %s] [request_id: %s] [err_no: %d] [trace_id: %s]",
-			res.ReqUrl, resp.RequestId, resp.Errno, t.GetTarceId(res.Header))
-		return nil, res, xbase.ComErrServRespErrnoErr
-	}
-
-	t.Logger.Trace("operate succ. [param: %+v] [url: %s] [request_id: %s] [trace_id: %s]",
-		param, res.ReqUrl, resp.RequestId, t.GetTarceId(res.Header))
 	return &resp, res, nil
 }
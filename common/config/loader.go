@@ -0,0 +1,351 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/xuperchain/xasset-sdk-go/common/httpcli"
+)
+
+const (
+	// 环境变量前缀，配置项对应的环境变量为前缀加大写的配置项名，如XASSET_ENDPOINT
+	EnvPrefix = "XASSET_"
+	// 指定配置文件路径及profile的环境变量
+	EnvConfigFile = "XASSET_CONFIG_FILE"
+	EnvProfile    = "XASSET_PROFILE"
+)
+
+var ErrConfigInvalid = errors.New("xasset config invalid")
+
+// ProfileConfig 配置文件、环境变量及显式覆盖共用的配置项，零值表示不设置
+// 布尔配置项使用指针，后合并的配置显式设置为false时可以关闭之前开启的配置
+type ProfileConfig struct {
+	Endpoint              string `yaml:"endpoint" json:"endpoint"`
+	UserAgent             string `yaml:"user_agent" json:"user_agent"`
+	AppId                 int64  `yaml:"app_id" json:"app_id"`
+	AccessKeyId           string `yaml:"access_key_id" json:"access_key_id"`
+	SecretAccessKey       string `yaml:"secret_access_key" json:"secret_access_key"`
+	SignExpireSeconds     int    `yaml:"sign_expire_seconds" json:"sign_expire_seconds"`
+	ConnectTimeoutMs      int    `yaml:"connect_timeout_ms" json:"connect_timeout_ms"`
+	ReadWriteTimeoutMs    int    `yaml:"read_write_timeout_ms" json:"read_write_timeout_ms"`
+	TlsHandshakeTimeoutMs int    `yaml:"tls_handshake_timeout_ms" json:"tls_handshake_timeout_ms"`
+	RespHeaderTimeoutMs   int    `yaml:"response_header_timeout_ms" json:"response_header_timeout_ms"`
+	IdleReadTimeoutMs     int    `yaml:"idle_read_timeout_ms" json:"idle_read_timeout_ms"`
+	RequestTimeoutMs      int    `yaml:"request_timeout_ms" json:"request_timeout_ms"`
+	MaxIdleConns          int    `yaml:"max_idle_conns" json:"max_idle_conns"`
+	MaxIdleConnsPerHost   int    `yaml:"max_idle_conns_per_host" json:"max_idle_conns_per_host"`
+	MaxConnsPerHost       int    `yaml:"max_conns_per_host" json:"max_conns_per_host"`
+	IdleConnTimeoutMs     int    `yaml:"idle_conn_timeout_ms" json:"idle_conn_timeout_ms"`
+	DisableKeepAlives     *bool  `yaml:"disable_keep_alives" json:"disable_keep_alives"`
+	TlsCaFile             string `yaml:"tls_ca_file" json:"tls_ca_file"`
+	TlsCertFile           string `yaml:"tls_cert_file" json:"tls_cert_file"`
+	TlsKeyFile            string `yaml:"tls_key_file" json:"tls_key_file"`
+	TlsInsecureSkipVerify *bool  `yaml:"tls_insecure_skip_verify" json:"tls_insecure_skip_verify"`
+	ProxyUrl              string `yaml:"proxy_url" json:"proxy_url"`
+	ProxyFromEnv          *bool  `yaml:"proxy_from_env" json:"proxy_from_env"`
+}
+
+// FileConfig 配置文件格式，顶层配置项为所有profile共用的配置，profiles中的同名配置项覆盖顶层配置
+//
+//	endpoint: https://xasset.example.com
+//	profile: dev
+//	profiles:
+//	  dev:
+//	    endpoint: http://127.0.0.1:8360
+//	  prod:
+//	    app_id: 1000
+type FileConfig struct {
+	ProfileConfig `yaml:",inline"`
+	// 默认使用的profile
+	Profile  string                    `yaml:"profile" json:"profile"`
+	Profiles map[string]*ProfileConfig `yaml:"profiles" json:"profiles"`
+}
+
+// LoadOptions 配置加载选项
+type LoadOptions struct {
+	// 配置文件路径，支持.yaml/.yml/.json，为空时读取XASSET_CONFIG_FILE，都为空时不读取配置文件
+	File string
+	// 使用的profile，为空时依次读取XASSET_PROFILE和配置文件中的profile
+	Profile string
+	// 不读取XASSET_*环境变量
+	DisableEnv bool
+	// 显式覆盖的配置项，优先级最高
+	Overrides *ProfileConfig
+}
+
+// LoadConfig 按默认值、配置文件顶层配置、profile配置、环境变量、显式覆盖的顺序合并配置并校验
+func LoadConfig(opt *LoadOptions) (*XassetCliConfig, error) {
+	if opt == nil {
+		opt = &LoadOptions{}
+	}
+	file, profile := opt.File, opt.Profile
+	if !opt.DisableEnv {
+		if file == "" {
+			file = os.Getenv(EnvConfigFile)
+		}
+		if profile == "" {
+			profile = os.Getenv(EnvProfile)
+		}
+	}
+
+	merged := &ProfileConfig{}
+	if file != "" {
+		fileCfg, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+		mergeProfile(merged, &fileCfg.ProfileConfig)
+		if profile == "" {
+			profile = fileCfg.Profile
+		}
+		if profile != "" {
+			profCfg, ok := fileCfg.Profiles[profile]
+			if !ok || profCfg == nil {
+				return nil, fmt.Errorf("%w: profile %q not found in %s", ErrConfigInvalid, profile, file)
+			}
+			mergeProfile(merged, profCfg)
+		}
+	} else if profile != "" {
+		return nil, fmt.Errorf("%w: profile %q specified without config file", ErrConfigInvalid, profile)
+	}
+
+	if !opt.DisableEnv {
+		envCfg, err := profileFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		mergeProfile(merged, envCfg)
+	}
+	if opt.Overrides != nil {
+		mergeProfile(merged, opt.Overrides)
+	}
+
+	cfg := NewXassetCliConf()
+	merged.apply(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func readConfigFile(file string) (*FileConfig, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read config file failed: %v", ErrConfigInvalid, err)
+	}
+
+	fileCfg := &FileConfig{}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, fileCfg)
+	case ".json":
+		err = json.Unmarshal(data, fileCfg)
+	default:
+		return nil, fmt.Errorf("%w: unsupported config file format %s, expect .yaml/.yml/.json",
+			ErrConfigInvalid, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parse config file %s failed: %v", ErrConfigInvalid, file, err)
+	}
+	return fileCfg, nil
+}
+
+// profileFromEnv 读取XASSET_*环境变量，变量名由配置项的yaml名转为大写得到
+func profileFromEnv() (*ProfileConfig, error) {
+	cfg := &ProfileConfig{}
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("yaml")
+		env := EnvPrefix + strings.ToUpper(name)
+		val, ok := os.LookupEnv(env)
+		if !ok || val == "" {
+			continue
+		}
+
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(val)
+		case reflect.Int, reflect.Int64:
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: env %s must be an integer, got %q", ErrConfigInvalid, env, val)
+			}
+			field.SetInt(n)
+		case reflect.Ptr:
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: env %s must be a bool, got %q", ErrConfigInvalid, env, val)
+			}
+			field.Set(reflect.ValueOf(&b))
+		}
+	}
+	return cfg, nil
+}
+
+// mergeProfile 用src中非零值及非nil的配置项覆盖dst
+func mergeProfile(dst, src *ProfileConfig) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		if !sv.Field(i).IsZero() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
+
+// apply 将非零值及非nil的配置项写入cfg
+func (t *ProfileConfig) apply(cfg *XassetCliConfig) {
+	if t.Endpoint != "" {
+		cfg.Endpoint = t.Endpoint
+	}
+	if t.UserAgent != "" {
+		cfg.UserAgent = t.UserAgent
+	}
+	if t.AppId != 0 || t.AccessKeyId != "" || t.SecretAccessKey != "" {
+		cfg.SetCredentials(t.AppId, t.AccessKeyId, t.SecretAccessKey)
+	}
+	if t.SignExpireSeconds != 0 {
+		cfg.SignOption.ExpireSeconds = t.SignExpireSeconds
+	}
+	if t.ConnectTimeoutMs != 0 {
+		cfg.ConnectTimeoutMs = t.ConnectTimeoutMs
+	}
+	if t.ReadWriteTimeoutMs != 0 {
+		cfg.ReadWriteTimeoutMs = t.ReadWriteTimeoutMs
+	}
+	if t.TlsHandshakeTimeoutMs != 0 {
+		cfg.TlsHandshakeTimeoutMs = t.TlsHandshakeTimeoutMs
+	}
+	if t.RespHeaderTimeoutMs != 0 {
+		cfg.ResponseHeaderTimeoutMs = t.RespHeaderTimeoutMs
+	}
+	if t.IdleReadTimeoutMs != 0 {
+		cfg.IdleReadTimeoutMs = t.IdleReadTimeoutMs
+	}
+	if t.RequestTimeoutMs != 0 {
+		cfg.RequestTimeoutMs = t.RequestTimeoutMs
+	}
+	if t.MaxIdleConns != 0 {
+		cfg.MaxIdleConns = t.MaxIdleConns
+	}
+	if t.MaxIdleConnsPerHost != 0 {
+		cfg.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	}
+	if t.MaxConnsPerHost != 0 {
+		cfg.MaxConnsPerHost = t.MaxConnsPerHost
+	}
+	if t.IdleConnTimeoutMs != 0 {
+		cfg.IdleConnTimeoutMs = t.IdleConnTimeoutMs
+	}
+	if t.DisableKeepAlives != nil {
+		cfg.DisableKeepAlives = *t.DisableKeepAlives
+	}
+	if t.TlsCaFile != "" {
+		cfg.TlsCaFile = t.TlsCaFile
+	}
+	if t.TlsCertFile != "" {
+		cfg.TlsCertFile = t.TlsCertFile
+	}
+	if t.TlsKeyFile != "" {
+		cfg.TlsKeyFile = t.TlsKeyFile
+	}
+	if t.TlsInsecureSkipVerify != nil {
+		cfg.TlsInsecureSkipVerify = *t.TlsInsecureSkipVerify
+	}
+	if t.ProxyUrl != "" {
+		cfg.ProxyUrl = t.ProxyUrl
+	}
+	if t.ProxyFromEnv != nil {
+		cfg.ProxyFromEnv = *t.ProxyFromEnv
+	}
+}
+
+// Bool 返回布尔配置项的指针，用于设置ProfileConfig
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Validate 校验配置，返回的错误列出所有不合法的配置项
+func (t *XassetCliConfig) Validate() error {
+	var errs []string
+	if len(t.Endpoints) > 0 {
+		for i, ep := range t.Endpoints {
+			if ep == nil || !isHttpUrl(ep.Url) {
+				errs = append(errs, fmt.Sprintf("endpoints[%d] must be an absolute http or https url", i))
+			}
+		}
+	} else if t.Endpoint == "" {
+		errs = append(errs, "endpoint is empty")
+	} else if !isHttpUrl(t.Endpoint) {
+		errs = append(errs, fmt.Sprintf("endpoint %q must be an absolute http or https url", t.Endpoint))
+	}
+	if t.Credentials == nil && t.CredentialsProvider == nil {
+		errs = append(errs, "credentials are not set")
+	} else if t.Credentials != nil {
+		if t.Credentials.AppId <= 0 {
+			errs = append(errs, "app_id must be positive")
+		}
+		if t.Credentials.AccessKeyId == "" {
+			errs = append(errs, "access_key_id is empty")
+		}
+		if t.Credentials.SecretAccessKey == "" {
+			errs = append(errs, "secret_access_key is empty")
+		}
+	}
+	if t.SignOption == nil {
+		errs = append(errs, "sign option is not set")
+	} else if t.SignOption.ExpireSeconds <= 0 {
+		errs = append(errs, "sign_expire_seconds must be positive")
+	}
+	for _, item := range []struct {
+		name string
+		val  int
+	}{
+		{"connect_timeout_ms", t.ConnectTimeoutMs},
+		{"read_write_timeout_ms", t.ReadWriteTimeoutMs},
+		{"tls_handshake_timeout_ms", t.TlsHandshakeTimeoutMs},
+		{"response_header_timeout_ms", t.ResponseHeaderTimeoutMs},
+		{"idle_read_timeout_ms", t.IdleReadTimeoutMs},
+		{"request_timeout_ms", t.RequestTimeoutMs},
+		{"max_idle_conns", t.MaxIdleConns},
+		{"max_idle_conns_per_host", t.MaxIdleConnsPerHost},
+		{"max_conns_per_host", t.MaxConnsPerHost},
+		{"idle_conn_timeout_ms", t.IdleConnTimeoutMs},
+		{"endpoint_fail_threshold", t.EndpointFailThreshold},
+		{"endpoint_probe_interval_ms", t.EndpointProbeIntervalMs},
+	} {
+		if item.val < 0 {
+			errs = append(errs, fmt.Sprintf("%s must not be negative", item.name))
+		}
+	}
+	if (t.TlsCertFile == "") != (t.TlsKeyFile == "") {
+		errs = append(errs, "tls_cert_file and tls_key_file must be set together")
+	}
+	if t.ProxyUrl != "" {
+		if _, err := httpcli.ParseProxyUrl(t.ProxyUrl); err != nil {
+			// 地址中可能带有密码，不输出原始配置
+			errs = append(errs, fmt.Sprintf("proxy_url invalid: %v", err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrConfigInvalid, strings.Join(errs, "; "))
+}
+
+func isHttpUrl(val string) bool {
+	u, err := url.Parse(val)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
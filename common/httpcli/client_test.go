@@ -1,8 +1,26 @@
 package httpcli
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestIsHttps(t *testing.T) {
@@ -18,3 +36,309 @@ func TestIsHttps(t *testing.T) {
 		fmt.Println(u, IsHttps(u))
 	}
 }
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPem []byte
+	keyPem  []byte
+}
+
+func genTestCert(t *testing.T, cn string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed.err:%v", err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tpl, key
+	if parent == nil {
+		tpl.IsCA = true
+		tpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate failed.err:%v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name string, data []byte) string {
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatalf("write file failed.err:%v", err)
+	}
+	return file
+}
+
+func TestNewTlsConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpcli")
+	if err != nil {
+		t.Fatalf("create temp dir failed.err:%v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca := genTestCert(t, "test ca", nil, x509.ExtKeyUsageAny)
+	srvCert := genTestCert(t, "127.0.0.1", ca, x509.ExtKeyUsageServerAuth)
+	cliCert := genTestCert(t, "client", ca, x509.ExtKeyUsageClientAuth)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	pair, _ := tls.X509KeyPair(srvCert.certPem, srvCert.keyPem)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caFile := writeTestFile(t, dir, "ca.pem", ca.certPem)
+	certFile := writeTestFile(t, dir, "client.pem", cliCert.certPem)
+	keyFile := writeTestFile(t, dir, "client.key", cliCert.keyPem)
+
+	cases := []struct {
+		caFile, certFile, keyFile string
+		succ                      bool
+	}{
+		{"", "", "", false},
+		{caFile, "", "", false},
+		{caFile, certFile, keyFile, true},
+	}
+	for idx, c := range cases {
+		tlsCfg, err := NewTlsConfig(c.caFile, c.certFile, c.keyFile, false)
+		if err != nil {
+			t.Fatalf("[index:%d] new tls config failed.err:%v", idx, err)
+		}
+		client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, TlsConfig: tlsCfg}), 3000, nil)
+		req, _ := GenRequest("GET", srv.URL, nil, "")
+		_, err = DoRequest(client, req)
+		if (err == nil) != c.succ {
+			t.Fatalf("[index:%d] unexpected tls result.err:%v", idx, err)
+		}
+	}
+
+	if _, err := NewTlsConfig(keyFile, "", "", false); err == nil {
+		t.Fatalf("invalid ca file should fail")
+	}
+	if tlsCfg, _ := NewTlsConfig("", "", "", false); tlsCfg.InsecureSkipVerify ||
+		tlsCfg.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("default tls config should verify server with tls1.2+")
+	}
+}
+
+func TestProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 经过代理的请求为绝对地址，并携带代理认证信息
+		user, pass, ok := (&http.Request{Header: http.Header{
+			"Authorization": r.Header["Proxy-Authorization"]}}).BasicAuth()
+		if r.URL.Host != "xasset.test:8360" || !ok || user != "user" || pass != "p@ss" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+	proxyUrl := "http://user:p%40ss@" + proxy.Listener.Addr().String()
+
+	fn, err := NewProxy(proxyUrl, false)
+	if err != nil || fn == nil {
+		t.Fatalf("new proxy failed.err:%v", err)
+	}
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, Proxy: fn}), 3000, nil)
+	req, _ := GenRequest("POST", "http://xasset.test:8360/xasset/horae/v1/query", nil, "a=1")
+	res, err := DoRequest(client, req)
+	if err != nil || res.StatusCode != 200 || string(res.Body) != "via proxy" {
+		t.Errorf("request via proxy failed.res:%+v err:%v", res, err)
+	}
+
+	req, _ = GenRequest("POST", "http://xasset.test:8360/xasset/horae/v1/query", nil, "a=1")
+	res, err = SendRequest(req, 1000, 3000, map[string]string{OptProxyUrl: proxyUrl})
+	if err != nil || string(res.Body) != "via proxy" {
+		t.Errorf("send request via proxy failed.res:%+v err:%v", res, err)
+	}
+
+	if fn, err := NewProxy("", true); err != nil || fn == nil {
+		t.Errorf("proxy from env should be set.err:%v", err)
+	}
+	if fn, err := NewProxy("", false); err != nil || fn != nil {
+		t.Errorf("proxy should be nil.err:%v", err)
+	}
+	for _, u := range []string{"proxy:3128", "ftp://proxy:21", "http://"} {
+		if _, err := NewProxy(u, false); err == nil {
+			t.Errorf("invalid proxy url should fail.url:%s", u)
+		}
+	}
+
+	if masked := MaskProxyUrl(proxyUrl); strings.Contains(masked, "p%40ss") || !strings.Contains(masked, "user:") {
+		t.Errorf("mask proxy url failed.url:%s", masked)
+	}
+	if MaskProxyUrl("http://proxy:3128") != "http://proxy:3128" {
+		t.Errorf("mask proxy url without password failed")
+	}
+}
+
+func TestCustomDialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var dialed []string
+	var lock sync.Mutex
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		lock.Lock()
+		dialed = append(dialed, addr)
+		lock.Unlock()
+		// 自定义解析：所有域名都连接到测试服务
+		var d net.Dialer
+		return d.DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, DialContext: dial}), 3000, nil)
+	req, _ := GenRequest("GET", "http://xasset.test:8360/", nil, "")
+	res, err := DoRequest(client, req)
+	if err != nil || string(res.Body) != "ok" || len(dialed) != 1 || dialed[0] != "xasset.test:8360" {
+		t.Errorf("custom dial failed.res:%+v dialed:%v err:%v", res, dialed, err)
+	}
+}
+
+func TestConnectTimeout(t *testing.T) {
+	// 自定义DialContext仍受建连超时限制
+	block := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 50, DialContext: block}), 5000, nil)
+	req, _ := GenRequest("GET", "http://xasset.test:8360/", nil, "")
+	start := time.Now()
+	if _, err := DoRequest(client, req); err == nil || time.Since(start) > time.Second {
+		t.Errorf("custom dial should time out.err:%v cost:%v", err, time.Since(start))
+	}
+}
+
+func TestTlsHandshakeTimeout(t *testing.T) {
+	// 只建立tcp连接，不响应ClientHello
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed.err:%v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, TlsHandshakeTimeoutMs: 50}), 5000, nil)
+	req, _ := GenRequest("GET", "https://"+ln.Addr().String()+"/", nil, "")
+	start := time.Now()
+	_, err = DoRequest(client, req)
+	if err == nil || !strings.Contains(err.Error(), "TLS handshake timeout") || time.Since(start) > time.Second {
+		t.Errorf("tls handshake should time out.err:%v cost:%v", err, time.Since(start))
+	}
+}
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, ResponseHeaderTimeoutMs: 50}), 5000, nil)
+	req, _ := GenRequest("GET", srv.URL+"/slow", nil, "")
+	if _, err := DoRequest(client, req); err == nil || !strings.Contains(err.Error(), "timeout awaiting response headers") {
+		t.Errorf("response header should time out.err:%v", err)
+	}
+	req, _ = GenRequest("GET", srv.URL+"/fast", nil, "")
+	if res, err := DoRequest(client, req); err != nil || string(res.Body) != "ok" {
+		t.Errorf("fast response failed.err:%v", err)
+	}
+}
+
+// newTrickleServer 先返回响应头，之后每隔interval写入一块数据，共写入chunks块
+func newTrickleServer(interval time.Duration, chunks int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		for i := 0; i < chunks; i++ {
+			w.Write([]byte("0123456789"))
+			w.(http.Flusher).Flush()
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+}
+
+func TestIdleReadTimeout(t *testing.T) {
+	// 持续接收数据的慢响应总耗时超过空闲超时也能成功
+	srv := newTrickleServer(30*time.Millisecond, 10)
+	defer srv.Close()
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000, ResponseHeaderTimeoutMs: 100}), 5000, nil)
+	req, _ := GenRequest("GET", srv.URL, nil, "")
+	res, err := DoRequestWithIdleTimeout(client, req, 100*time.Millisecond)
+	if err != nil || len(res.Body) != 100 {
+		t.Errorf("slow response should succeed.len:%d err:%v", len(res.Body), err)
+	}
+
+	// 响应中途停止发送数据
+	stall := newTrickleServer(2*time.Second, 2)
+	defer stall.Close()
+	req, _ = GenRequest("GET", stall.URL, nil, "")
+	start := time.Now()
+	_, err = DoRequestWithIdleTimeout(client, req, 100*time.Millisecond)
+	if err != ErrIdleReadTimeout || time.Since(start) > time.Second {
+		t.Errorf("stalled response should time out.err:%v cost:%v", err, time.Since(start))
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	// 持续发送数据，但整体耗时超过请求超时
+	srv := newTrickleServer(30*time.Millisecond, 100)
+	defer srv.Close()
+	client := NewClient(NewTransport(&TransportOptions{ConnTimeoutMs: 1000}), 200, nil)
+	req, _ := GenRequest("GET", srv.URL, nil, "")
+	start := time.Now()
+	_, err := DoRequestWithIdleTimeout(client, req, 100*time.Millisecond)
+	if err == nil || err == ErrIdleReadTimeout || time.Since(start) > time.Second {
+		t.Errorf("request should hit overall deadline.err:%v cost:%v", err, time.Since(start))
+	}
+}
+
+func TestSendRequestTimeout(t *testing.T) {
+	// 读写超时不再限制连接的整个生命周期
+	srv := newTrickleServer(30*time.Millisecond, 10)
+	defer srv.Close()
+	req, _ := GenRequest("GET", srv.URL, nil, "")
+	res, err := SendRequest(req, 1000, 100, nil)
+	if err != nil || len(res.Body) != 100 {
+		t.Errorf("slow response should succeed.len:%d err:%v", len(res.Body), err)
+	}
+}
@@ -0,0 +1,162 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// fmtFields 将字段格式化为[key:value]形式，与SDK原有日志格式一致
+func fmtFields(fields []Field) string {
+	var buf bytes.Buffer
+	for _, f := range fields {
+		fmt.Fprintf(&buf, " [%s:%v]", f.Key, f.Value)
+	}
+	return buf.String()
+}
+
+// driverAdapter 将结构化日志转为LogDriver的字符串日志
+type driverAdapter struct {
+	driver LogDriver
+}
+
+// NewLogDriverAdapter 使用已有的LogDriver输出结构化日志，字段追加在消息后
+func NewLogDriverAdapter(driver LogDriver) StructuredLogger {
+	return &driverAdapter{driver: driver}
+}
+
+func (t *driverAdapter) Log(level Level, msg string, fields ...Field) {
+	if t.driver == nil {
+		return
+	}
+
+	// LogDriver按格式串处理消息，转义已格式化内容中的%
+	line := strings.ReplaceAll(msg+fmtFields(fields), "%", "%%")
+	switch level {
+	case LevelError:
+		t.driver.Error(line)
+	case LevelWarn:
+		t.driver.Warn(line)
+	case LevelInfo:
+		t.driver.Info(line)
+	case LevelTrace:
+		t.driver.Trace(line)
+	default:
+		t.driver.Debug(line)
+	}
+}
+
+// printfDriver 为结构化日志适配器实现LogDriver，便于直接传给NewAssetOperCli等构造函数
+type printfDriver struct {
+	StructuredLogger
+}
+
+func (t printfDriver) Error(msg string, ctx ...interface{}) {
+	t.Log(LevelError, fmt.Sprintf(msg, ctx...))
+}
+
+func (t printfDriver) Warn(msg string, ctx ...interface{}) {
+	t.Log(LevelWarn, fmt.Sprintf(msg, ctx...))
+}
+
+func (t printfDriver) Info(msg string, ctx ...interface{}) {
+	t.Log(LevelInfo, fmt.Sprintf(msg, ctx...))
+}
+
+func (t printfDriver) Trace(msg string, ctx ...interface{}) {
+	t.Log(LevelTrace, fmt.Sprintf(msg, ctx...))
+}
+
+func (t printfDriver) Debug(msg string, ctx ...interface{}) {
+	t.Log(LevelDebug, fmt.Sprintf(msg, ctx...))
+}
+
+// StructuredDriver 同时实现LogDriver和StructuredLogger
+type StructuredDriver interface {
+	LogDriver
+	StructuredLogger
+}
+
+// NewStructuredDriver 将结构化日志实现包装为可以传给客户端构造函数的LogDriver
+func NewStructuredDriver(logger StructuredLogger) StructuredDriver {
+	return printfDriver{StructuredLogger: logger}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  Level
+}
+
+// NewStdLogger 使用标准库log输出，低于level的日志不输出，logger为nil时使用log包默认logger
+func NewStdLogger(logger *log.Logger, level Level) StructuredDriver {
+	return NewStructuredDriver(&stdLogger{logger: logger, level: level})
+}
+
+func (t *stdLogger) Log(level Level, msg string, fields ...Field) {
+	if level < t.level {
+		return
+	}
+
+	line := fmt.Sprintf("[lvl:%s] %s%s", level, msg, fmtFields(fields))
+	if t.logger == nil {
+		log.Print(line)
+		return
+	}
+	t.logger.Print(line)
+}
+
+// KeyValueLogger 键值对形式的结构化日志接口，zap.SugaredLogger可以直接使用
+type KeyValueLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type keyValueAdapter struct {
+	logger KeyValueLogger
+}
+
+// NewKeyValueLogger 适配zap.SugaredLogger等键值对日志，Trace级别输出为Debug
+func NewKeyValueLogger(logger KeyValueLogger) StructuredDriver {
+	return NewStructuredDriver(&keyValueAdapter{logger: logger})
+}
+
+func (t *keyValueAdapter) Log(level Level, msg string, fields ...Field) {
+	kvs := make([]interface{}, 0, 2*len(fields))
+	for _, f := range fields {
+		kvs = append(kvs, f.Key, f.Value)
+	}
+
+	switch level {
+	case LevelError:
+		t.logger.Errorw(msg, kvs...)
+	case LevelWarn:
+		t.logger.Warnw(msg, kvs...)
+	case LevelInfo:
+		t.logger.Infow(msg, kvs...)
+	default:
+		t.logger.Debugw(msg, kvs...)
+	}
+}
+
+// LogFunc 以map接收字段的日志函数，用于适配logrus、zerolog等日志库，如
+//
+//	logs.NewFuncLogger(func(level logs.Level, msg string, fields map[string]interface{}) {
+//		logrus.WithFields(fields).Warn(msg)
+//	})
+type LogFunc func(level Level, msg string, fields map[string]interface{})
+
+// NewFuncLogger 使用日志函数输出结构化日志
+func NewFuncLogger(fn LogFunc) StructuredDriver {
+	return NewStructuredDriver(fn)
+}
+
+func (fn LogFunc) Log(level Level, msg string, fields ...Field) {
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	fn(level, msg, m)
+}
@@ -0,0 +1,117 @@
+package logs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+type captureDriver struct {
+	lines []string
+}
+
+func (t *captureDriver) add(lvl, msg string, ctx ...interface{}) {
+	t.lines = append(t.lines, lvl+" "+fmt.Sprintf(msg, ctx...))
+}
+
+func (t *captureDriver) Error(msg string, ctx ...interface{}) { t.add("Error", msg, ctx...) }
+func (t *captureDriver) Warn(msg string, ctx ...interface{})  { t.add("Warn", msg, ctx...) }
+func (t *captureDriver) Info(msg string, ctx ...interface{})  { t.add("Info", msg, ctx...) }
+func (t *captureDriver) Trace(msg string, ctx ...interface{}) { t.add("Trace", msg, ctx...) }
+func (t *captureDriver) Debug(msg string, ctx ...interface{}) { t.add("Debug", msg, ctx...) }
+
+func TestLogDriverAdapter(t *testing.T) {
+	driver := &captureDriver{}
+	logger := NewLogger(driver)
+	logger.Log(LevelWarn, "call failed.", String(FieldUri, "/v1/query"), Int(FieldErrno, 10002),
+		String("body", "100%d"), LatencyMs(1500*time.Millisecond), Err(errors.New("boom")))
+	logger.Info("legacy msg.[id:%d]", 1)
+
+	if len(driver.lines) != 2 {
+		t.Fatalf("log lines not match.lines:%v", driver.lines)
+	}
+	line := driver.lines[0]
+	if !strings.HasPrefix(line, "Warn call failed. [uri:/v1/query] [errno:10002] [body:100%d] [latency_ms:1500] [err:boom] [sdk_call:") {
+		t.Errorf("structured line not match.line:%s", line)
+	}
+	if !strings.HasPrefix(driver.lines[1], "Info legacy msg.[id:1] [sdk_call:") ||
+		!strings.Contains(driver.lines[1], "adapter_test.go") {
+		t.Errorf("printf line not match.line:%s", driver.lines[1])
+	}
+}
+
+func TestFuncLogger(t *testing.T) {
+	var gotLevel Level
+	var gotMsg string
+	var gotFields map[string]interface{}
+	logger := NewLogger(NewFuncLogger(func(level Level, msg string, fields map[string]interface{}) {
+		gotLevel, gotMsg, gotFields = level, msg, fields
+	}))
+
+	logger.Log(LevelError, "failed.", String(FieldAssetId, "1"),
+		String("body", `{"secret_access_key":"sk-secret"}`))
+	if gotLevel != LevelError || gotMsg != "failed." || gotFields[FieldAssetId] != "1" {
+		t.Errorf("structured log not match.level:%v msg:%s fields:%v", gotLevel, gotMsg, gotFields)
+	}
+	if strings.Contains(fmt.Sprint(gotFields["body"]), "sk-secret") {
+		t.Errorf("string field not redacted.fields:%v", gotFields)
+	}
+	if call, _ := gotFields[FieldSdkCall].(string); !strings.Contains(call, "adapter_test.go") {
+		t.Errorf("sdk_call not match.fields:%v", gotFields)
+	}
+
+	logger.Trace("legacy msg.[id:%d]", 2)
+	if gotLevel != LevelTrace || gotMsg != "legacy msg.[id:2]" {
+		t.Errorf("printf log not match.level:%v msg:%s", gotLevel, gotMsg)
+	}
+	if call, _ := gotFields[FieldSdkCall].(string); !strings.Contains(call, "adapter_test.go") {
+		t.Errorf("sdk_call not match.fields:%v", gotFields)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(NewStdLogger(log.New(&buf, "", 0), LevelInfo))
+	logger.Debug("debug msg")
+	logger.Log(LevelTrace, "trace msg")
+	logger.Log(LevelWarn, "warn msg", String(FieldRequestId, "r1"))
+
+	out := buf.String()
+	if strings.Contains(out, "debug msg") || strings.Contains(out, "trace msg") {
+		t.Errorf("low level log should be dropped.out:%s", out)
+	}
+	if !strings.HasPrefix(out, "[lvl:Warn] warn msg [request_id:r1] [sdk_call:") {
+		t.Errorf("std log not match.out:%s", out)
+	}
+}
+
+type kvLogger struct {
+	level string
+	msg   string
+	kvs   []interface{}
+}
+
+func (t *kvLogger) Debugw(msg string, kvs ...interface{}) { t.level, t.msg, t.kvs = "debug", msg, kvs }
+func (t *kvLogger) Infow(msg string, kvs ...interface{})  { t.level, t.msg, t.kvs = "info", msg, kvs }
+func (t *kvLogger) Warnw(msg string, kvs ...interface{})  { t.level, t.msg, t.kvs = "warn", msg, kvs }
+func (t *kvLogger) Errorw(msg string, kvs ...interface{}) { t.level, t.msg, t.kvs = "error", msg, kvs }
+
+func TestKeyValueLogger(t *testing.T) {
+	kv := &kvLogger{}
+	logger := NewLogger(NewKeyValueLogger(kv))
+	logger.Log(LevelTrace, "succ.", String(FieldUri, "/v1/query"))
+	if kv.level != "debug" || kv.msg != "succ." || len(kv.kvs) != 4 ||
+		kv.kvs[0] != FieldUri || kv.kvs[1] != "/v1/query" || kv.kvs[2] != FieldSdkCall {
+		t.Errorf("key value log not match.log:%+v", kv)
+	}
+}
+
+func TestNilLogger(t *testing.T) {
+	logger := NewLogger(nil)
+	logger.Warn("msg")
+	logger.Log(LevelWarn, "msg", String(FieldUri, "/v1/query"))
+}
@@ -0,0 +1,87 @@
+package logs
+
+import (
+	"time"
+)
+
+// 日志级别
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelTrace
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "Debug"
+	case LevelTrace:
+		return "Trace"
+	case LevelInfo:
+		return "Info"
+	case LevelWarn:
+		return "Warn"
+	case LevelError:
+		return "Error"
+	}
+	return "Unknown"
+}
+
+// 接口请求日志统一使用的字段名
+const (
+	FieldUri       = "uri"
+	FieldAssetId   = "asset_id"
+	FieldShardId   = "shard_id"
+	FieldRequestId = "request_id"
+	FieldTraceId   = "trace_id"
+	FieldLatencyMs = "latency_ms"
+	FieldErrno     = "errno"
+	FieldHttpCode  = "http_code"
+	FieldErr       = "err"
+	FieldSdkCall   = "sdk_call"
+)
+
+// Field 日志字段
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, val string) Field {
+	return Field{Key: key, Value: val}
+}
+
+func Int(key string, val int) Field {
+	return Field{Key: key, Value: val}
+}
+
+func Int64(key string, val int64) Field {
+	return Field{Key: key, Value: val}
+}
+
+func Any(key string, val interface{}) Field {
+	return Field{Key: key, Value: val}
+}
+
+// Err 错误字段，err为nil时值为空字符串
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: FieldErr, Value: ""}
+	}
+	return Field{Key: FieldErr, Value: err.Error()}
+}
+
+// LatencyMs 耗时字段，单位毫秒
+func LatencyMs(d time.Duration) Field {
+	return Field{Key: FieldLatencyMs, Value: d.Milliseconds()}
+}
+
+// StructuredLogger 结构化日志接口，字段以键值对的形式传给底层日志库，便于日志系统检索
+// 传给客户端的LogDriver同时实现了该接口时，SDK优先使用结构化日志
+type StructuredLogger interface {
+	Log(level Level, msg string, fields ...Field)
+}
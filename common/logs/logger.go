@@ -16,64 +16,89 @@ type LogDriver interface {
 }
 
 type Logger struct {
-	logDriver LogDriver
+	logDriver  LogDriver
+	structured StructuredLogger
 }
 
+// NewLogger logDriver同时实现了StructuredLogger时输出结构化日志，
+// 否则字段以[key:value]的形式追加在消息后
 func NewLogger(logDriver LogDriver) *Logger {
-	return &Logger{
+	logger := &Logger{
 		logDriver: logDriver,
 	}
+	if sl, ok := logDriver.(StructuredLogger); ok {
+		logger.structured = sl
+	} else if logDriver != nil {
+		logger.structured = NewLogDriverAdapter(logDriver)
+	}
+	return logger
 }
 
 func (t *Logger) Error(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
-
-	t.logDriver.Error(t.fmtMsg(msg, ctx...))
+	t.logf(LevelError, msg, ctx...)
 }
 
 func (t *Logger) Warn(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
-
-	t.logDriver.Warn(t.fmtMsg(msg, ctx...))
+	t.logf(LevelWarn, msg, ctx...)
 }
 
 func (t *Logger) Info(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
-		return
-	}
-
-	t.logDriver.Info(t.fmtMsg(msg, ctx...))
+	t.logf(LevelInfo, msg, ctx...)
 }
 
 func (t *Logger) Trace(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
+	t.logf(LevelTrace, msg, ctx...)
+}
+
+func (t *Logger) Debug(msg string, ctx ...interface{}) {
+	t.logf(LevelDebug, msg, ctx...)
+}
+
+// Log 输出结构化日志，字符串字段同样会隐藏敏感信息
+func (t *Logger) Log(level Level, msg string, fields ...Field) {
+	if t.structured == nil {
 		return
 	}
 
-	t.logDriver.Trace(t.fmtMsg(msg, ctx...))
+	redacted := make([]Field, 0, len(fields)+1)
+	for _, f := range fields {
+		if s, ok := f.Value.(string); ok {
+			f.Value = Redact(s)
+		}
+		redacted = append(redacted, f)
+	}
+	redacted = append(redacted, String(FieldSdkCall, sdkCall(3)))
+	t.structured.Log(level, Redact(msg), redacted...)
 }
 
-func (t *Logger) Debug(msg string, ctx ...interface{}) {
-	if t.logDriver == nil {
+func (t *Logger) logf(level Level, msg string, ctx ...interface{}) {
+	if t.structured == nil {
 		return
 	}
 
-	t.logDriver.Debug(t.fmtMsg(msg, ctx...))
+	call := sdkCall(4)
+	if _, ok := t.structured.(*driverAdapter); ok {
+		// 保持LogDriver原有的日志格式
+		t.structured.Log(level, t.fmtMsg(msg, call, ctx...))
+		return
+	}
+	if msg != "" {
+		msg = Redact(fmt.Sprintf(msg, ctx...))
+	}
+	t.structured.Log(level, msg, String(FieldSdkCall, call))
 }
 
-func (t *Logger) genBaseMsg() string {
-	call, _ := utils.GetFuncCall(4)
-	return fmt.Sprintf("[sdk_call:%s]", call)
+// sdkCall 返回SDK调用方的文件及行号，callDepth为相对sdkCall的调用层数
+func sdkCall(callDepth int) string {
+	call, _ := utils.GetFuncCall(callDepth)
+	return call
 }
 
-func (t *Logger) fmtMsg(msg string, ctx ...interface{}) string {
+func (t *Logger) fmtMsg(msg, call string, ctx ...interface{}) string {
+	baseMsg := fmt.Sprintf("[sdk_call:%s]", call)
 	if msg == "" {
-		return t.genBaseMsg()
+		return baseMsg
 	}
 
-	return fmt.Sprintf(msg+" "+t.genBaseMsg(), ctx...)
+	return Redact(fmt.Sprintf(msg+" "+baseMsg, ctx...))
 }
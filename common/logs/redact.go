@@ -0,0 +1,27 @@
+package logs
+
+import (
+	"regexp"
+)
+
+const redactedMask = "******"
+
+var (
+	// json响应体中的敏感字段，如stoken接口返回的临时sk
+	jsonSecretRegexp = regexp.MustCompile(
+		`("(?:secret_access_key|session_token|private_key|mnemonic|sk)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// 表单请求体中的敏感字段
+	formSecretRegexp = regexp.MustCompile(
+		`\b((?:secret_access_key|session_token|private_key|mnemonic)=)[^&\s\]]*`)
+	// bce-auth-v1/{ak}/{timestamp}/{expire}/{signed_headers}/{signature}，隐藏签名
+	authStringRegexp = regexp.MustCompile(`(bce-auth-v1/[^/\s]*/[^/\s]*/[^/\s]*/[^/\s]*/)[0-9a-fA-F]+`)
+)
+
+// Redact 隐藏日志中的sk、session token、私钥、助记词及签名串
+// 结构体中的敏感字段由结构体的String方法隐藏，这里处理响应体、请求体等原始字符串
+func Redact(msg string) string {
+	msg = jsonSecretRegexp.ReplaceAllString(msg, `$1"`+redactedMask+`"`)
+	msg = formSecretRegexp.ReplaceAllString(msg, `${1}`+redactedMask)
+	msg = authStringRegexp.ReplaceAllString(msg, `${1}`+redactedMask)
+	return msg
+}
@@ -0,0 +1,31 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	cases := []struct {
+		msg    string
+		secret string
+	}{
+		{`[body:{"accessInfo":{"secret_access_key":"sk-secret","session_token":"token-secret"}}]`, "sk-secret"},
+		{`[body:{"accessInfo":{"secret_access_key":"sk-secret","session_token":"token-secret"}}]`, "token-secret"},
+		{`[body:{"mnemonic":"词 语 助 记"}]`, "词 语 助 记"},
+		{`[body:{"private_key" : "{\"D\":\"123\"}"}]`, `123`},
+		{`[data:addr=abc&mnemonic=encoded-mnem&open_id=1]`, "encoded-mnem"},
+		{`[auth:bce-auth-v1/ak/2022-01-01T00:00:00Z/1800/host;content-md5/0a1b2c3d4e5f]`, "0a1b2c3d4e5f"},
+	}
+	for i, c := range cases {
+		got := Redact(c.msg)
+		if strings.Contains(got, c.secret) || !strings.Contains(got, redactedMask) {
+			t.Errorf("case %d redact failed.msg:%s", i, got)
+		}
+	}
+
+	msg := `[url:http://127.0.0.1/xasset/horae/v1/query] [addr:abc&open_id=1]`
+	if Redact(msg) != msg {
+		t.Errorf("redact should not change normal msg.msg:%s", Redact(msg))
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"time"
+)
+
+// Call 一次接口调用的指标
+type Call struct {
+	// 接口名，如AssetApiGrant
+	Endpoint string
+	// http状态码，请求未收到响应时为0
+	HttpCode int
+	// 服务端错误码，成功为0
+	Errno   int
+	Latency time.Duration
+	// 请求是否失败，包括网络错误、非200响应及非0错误码
+	Failed bool
+}
+
+// Collector 接口调用指标收集器，实现需要并发安全
+type Collector interface {
+	Observe(call *Call)
+}
+
+type noopCollector struct{}
+
+func (noopCollector) Observe(call *Call) {}
+
+// NewNoopCollector 不记录任何指标，客户端默认使用
+func NewNoopCollector() Collector {
+	return noopCollector{}
+}
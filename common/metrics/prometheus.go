@@ -0,0 +1,185 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 默认的耗时分桶，单位秒
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+const promContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type requestKey struct {
+	endpoint string
+	httpCode int
+	errno    int
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// PrometheusCollector 以Prometheus文本格式输出指标，可以直接注册为/metrics的http handler，
+// 不依赖prometheus客户端库。输出的指标为
+//
+//	<namespace>_requests_total{endpoint,http_code,errno} 请求总数
+//	<namespace>_request_failures_total{endpoint} 失败请求数
+//	<namespace>_request_duration_seconds{endpoint} 请求耗时分布
+type PrometheusCollector struct {
+	namespace string
+	buckets   []float64
+
+	lock      sync.Mutex
+	requests  map[requestKey]uint64
+	failures  map[string]uint64
+	latencies map[string]*histogram
+}
+
+// NewPrometheusCollector namespace为空时使用xasset_sdk，buckets为空时使用DefaultLatencyBuckets
+func NewPrometheusCollector(namespace string, buckets []float64) *PrometheusCollector {
+	if namespace == "" {
+		namespace = "xasset_sdk"
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+
+	return &PrometheusCollector{
+		namespace: namespace,
+		buckets:   sorted,
+		requests:  make(map[requestKey]uint64),
+		failures:  make(map[string]uint64),
+		latencies: make(map[string]*histogram),
+	}
+}
+
+func (t *PrometheusCollector) Observe(call *Call) {
+	if call == nil {
+		return
+	}
+	seconds := call.Latency.Seconds()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.requests[requestKey{call.Endpoint, call.HttpCode, call.Errno}]++
+	if call.Failed {
+		t.failures[call.Endpoint]++
+	}
+	h, ok := t.latencies[call.Endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(t.buckets))}
+		t.latencies[call.Endpoint] = h
+	}
+	for i, le := range t.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// WriteTo 按Prometheus文本格式输出所有指标
+func (t *PrometheusCollector) WriteTo(w io.Writer) (int64, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	name := t.namespace + "_requests_total"
+	fmt.Fprintf(cw, "# HELP %s Total number of xasset api requests.\n# TYPE %s counter\n", name, name)
+	keys := make([]requestKey, 0, len(t.requests))
+	for k := range t.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		if keys[i].httpCode != keys[j].httpCode {
+			return keys[i].httpCode < keys[j].httpCode
+		}
+		return keys[i].errno < keys[j].errno
+	})
+	for _, k := range keys {
+		fmt.Fprintf(cw, "%s{endpoint=%s,http_code=\"%d\",errno=\"%d\"} %d\n",
+			name, quoteLabel(k.endpoint), k.httpCode, k.errno, t.requests[k])
+	}
+
+	name = t.namespace + "_request_failures_total"
+	fmt.Fprintf(cw, "# HELP %s Total number of failed xasset api requests.\n# TYPE %s counter\n", name, name)
+	for _, ep := range sortedKeys(t.failures) {
+		fmt.Fprintf(cw, "%s{endpoint=%s} %d\n", name, quoteLabel(ep), t.failures[ep])
+	}
+
+	name = t.namespace + "_request_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Latency of xasset api requests in seconds.\n# TYPE %s histogram\n", name, name)
+	endpoints := make([]string, 0, len(t.latencies))
+	for ep := range t.latencies {
+		endpoints = append(endpoints, ep)
+	}
+	sort.Strings(endpoints)
+	for _, ep := range endpoints {
+		h := t.latencies[ep]
+		label := quoteLabel(ep)
+		for i, le := range t.buckets {
+			fmt.Fprintf(cw, "%s_bucket{endpoint=%s,le=\"%s\"} %d\n",
+				name, label, strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(cw, "%s_bucket{endpoint=%s,le=\"+Inf\"} %d\n", name, label, h.count)
+		fmt.Fprintf(cw, "%s_sum{endpoint=%s} %s\n", name, label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(cw, "%s_count{endpoint=%s} %d\n", name, label, h.count)
+	}
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// ServeHTTP 输出指标，可以注册为Prometheus抓取的/metrics
+func (t *PrometheusCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", promContentType)
+	t.WriteTo(w)
+}
+
+func sortedKeys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteLabel(val string) string {
+	return `"` + labelEscaper.Replace(val) + `"`
+}
+
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (t *countWriter) Write(p []byte) (int, error) {
+	if t.err != nil {
+		return 0, t.err
+	}
+	n, err := t.w.Write(p)
+	t.n += int64(n)
+	t.err = err
+	return n, err
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	c := NewPrometheusCollector("", []float64{0.1, 0.01})
+	c.Observe(&Call{Endpoint: "AssetApiGrant", HttpCode: 200, Latency: 5 * time.Millisecond})
+	c.Observe(&Call{Endpoint: "AssetApiGrant", HttpCode: 200, Errno: 10002, Latency: 50 * time.Millisecond, Failed: true})
+	c.Observe(&Call{Endpoint: "HubCreateOrder", HttpCode: 0, Latency: time.Second, Failed: true})
+	c.Observe(nil)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("write metrics failed.err:%v", err)
+	}
+	out := buf.String()
+	want := []string{
+		"# TYPE xasset_sdk_requests_total counter",
+		`xasset_sdk_requests_total{endpoint="AssetApiGrant",http_code="200",errno="0"} 1`,
+		`xasset_sdk_requests_total{endpoint="AssetApiGrant",http_code="200",errno="10002"} 1`,
+		`xasset_sdk_requests_total{endpoint="HubCreateOrder",http_code="0",errno="0"} 1`,
+		`xasset_sdk_request_failures_total{endpoint="AssetApiGrant"} 1`,
+		`xasset_sdk_request_failures_total{endpoint="HubCreateOrder"} 1`,
+		"# TYPE xasset_sdk_request_duration_seconds histogram",
+		`xasset_sdk_request_duration_seconds_bucket{endpoint="AssetApiGrant",le="0.01"} 1`,
+		`xasset_sdk_request_duration_seconds_bucket{endpoint="AssetApiGrant",le="0.1"} 2`,
+		`xasset_sdk_request_duration_seconds_bucket{endpoint="AssetApiGrant",le="+Inf"} 2`,
+		`xasset_sdk_request_duration_seconds_sum{endpoint="AssetApiGrant"} 0.055`,
+		`xasset_sdk_request_duration_seconds_count{endpoint="AssetApiGrant"} 2`,
+		`xasset_sdk_request_duration_seconds_bucket{endpoint="HubCreateOrder",le="0.1"} 0`,
+		`xasset_sdk_request_duration_seconds_bucket{endpoint="HubCreateOrder",le="+Inf"} 1`,
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w+"\n") {
+			t.Errorf("metrics output missing line.line:%s\noutput:\n%s", w, out)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Body.String() != out || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("serve http not match.header:%v", rec.Header())
+	}
+}
+
+func TestPrometheusCollectorConcurrent(t *testing.T) {
+	c := NewPrometheusCollector("test", nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Observe(&Call{Endpoint: `a"b`, HttpCode: 200, Latency: time.Millisecond})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	c.WriteTo(&buf)
+	if !strings.Contains(buf.String(), `test_requests_total{endpoint="a\"b",http_code="200",errno="0"} 800`) {
+		t.Errorf("concurrent observe not match.output:%s", buf.String())
+	}
+}
+
+func TestNoopCollector(t *testing.T) {
+	NewNoopCollector().Observe(&Call{Endpoint: "AssetApiGrant"})
+}
@@ -0,0 +1,182 @@
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// W3C Trace Context的header名
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+type TraceId [16]byte
+
+func (t TraceId) IsValid() bool {
+	return t != TraceId{}
+}
+
+func (t TraceId) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+type SpanId [8]byte
+
+func (t SpanId) IsValid() bool {
+	return t != SpanId{}
+}
+
+func (t SpanId) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanContext 跨进程传递的span标识
+type SpanContext struct {
+	TraceId    TraceId
+	SpanId     SpanId
+	Sampled    bool
+	TraceState string
+	// 是否为从上游请求解析得到的span
+	Remote bool
+}
+
+func (t SpanContext) IsValid() bool {
+	return t.TraceId.IsValid() && t.SpanId.IsValid()
+}
+
+// Attribute span属性
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, val string) Attribute {
+	return Attribute{Key: key, Value: val}
+}
+
+func Int(key string, val int) Attribute {
+	return Attribute{Key: key, Value: val}
+}
+
+func Int64(key string, val int64) Attribute {
+	return Attribute{Key: key, Value: val}
+}
+
+// Span 一次操作的追踪记录，实现需要并发安全
+type Span interface {
+	SpanContext() SpanContext
+	SetAttributes(attrs ...Attribute)
+	// RecordError 记录错误并将span标记为失败
+	RecordError(err error)
+	End()
+}
+
+// Tracer 创建span，ctx中有span时新建的span作为其子span
+// 可以实现该接口接入OpenTelemetry等追踪系统
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan 将span存入ctx
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext 返回ctx中的span，没有时返回nil
+func SpanFromContext(ctx context.Context) Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// ContextWithRemoteSpanContext 将上游请求的span标识存入ctx，作为后续span的父span
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	sc.Remote = true
+	return ContextWithSpan(ctx, remoteSpan{sc: sc})
+}
+
+// remoteSpan 只携带上游span标识，不会被导出
+type remoteSpan struct {
+	sc SpanContext
+}
+
+func (t remoteSpan) SpanContext() SpanContext         { return t.sc }
+func (t remoteSpan) SetAttributes(attrs ...Attribute) {}
+func (t remoteSpan) RecordError(err error)            {}
+func (t remoteSpan) End()                             {}
+
+// Inject 将ctx中span的标识按W3C Trace Context格式写入header
+func Inject(ctx context.Context, header http.Header) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+
+	header.Set(TraceparentHeader, FormatTraceparent(sc))
+	if sc.TraceState != "" {
+		header.Set(TracestateHeader, sc.TraceState)
+	}
+}
+
+// Extract 从header中解析W3C Trace Context，header不合法时返回false
+func Extract(header http.Header) (SpanContext, bool) {
+	sc, err := ParseTraceparent(header.Get(TraceparentHeader))
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState = header.Get(TracestateHeader)
+	sc.Remote = true
+	return sc, true
+}
+
+// FormatTraceparent 格式为version-trace_id-parent_id-flags，如
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func FormatTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceId, sc.SpanId, flags)
+}
+
+func ParseTraceparent(val string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(val), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		(parts[0] == "00" && len(parts) != 4) {
+		return sc, fmt.Errorf("invalid traceparent %q", val)
+	}
+	if _, err := hex.Decode(make([]byte, 1), []byte(parts[0])); err != nil {
+		return sc, fmt.Errorf("invalid traceparent version %q", val)
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, fmt.Errorf("invalid traceparent %q", val)
+	}
+	if _, err := hex.Decode(sc.TraceId[:], []byte(parts[1])); err != nil {
+		return sc, fmt.Errorf("invalid trace id %q", val)
+	}
+	if _, err := hex.Decode(sc.SpanId[:], []byte(parts[2])); err != nil {
+		return sc, fmt.Errorf("invalid parent id %q", val)
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return sc, fmt.Errorf("invalid trace flags %q", val)
+	}
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("invalid traceparent %q", val)
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+	return sc, nil
+}
@@ -0,0 +1,94 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTraceparent(t *testing.T) {
+	val := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(val)
+	if err != nil || !sc.Sampled || sc.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" ||
+		sc.SpanId.String() != "00f067aa0ba902b7" {
+		t.Fatalf("parse traceparent failed.sc:%+v err:%v", sc, err)
+	}
+	if FormatTraceparent(sc) != val {
+		t.Errorf("format traceparent not match.got:%s", FormatTraceparent(sc))
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	}
+	for _, v := range invalid {
+		if _, err := ParseTraceparent(v); err == nil {
+			t.Errorf("invalid traceparent should fail.val:%s", v)
+		}
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set(TracestateHeader, "vendor=1")
+	parent, ok := Extract(header)
+	if !ok || !parent.Remote || parent.TraceState != "vendor=1" {
+		t.Fatalf("extract failed.sc:%+v", parent)
+	}
+
+	exporter := NewInMemoryExporter()
+	ctx := ContextWithRemoteSpanContext(context.Background(), parent)
+	ctx, span := NewTracer(exporter).Start(ctx, "op")
+	out := http.Header{}
+	Inject(ctx, out)
+	sc, ok := Extract(out)
+	if !ok || sc.TraceId != parent.TraceId || sc.SpanId != span.SpanContext().SpanId ||
+		out.Get(TracestateHeader) != "vendor=1" {
+		t.Errorf("inject not match.header:%v", out)
+	}
+
+	empty := http.Header{}
+	Inject(context.Background(), empty)
+	if len(empty) != 0 {
+		t.Errorf("inject without span should not set header.header:%v", empty)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttributes(String("k", "v"), Int("n", 1))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	child.End()
+	child.SetAttributes(String("k", "after end"))
+	root.End()
+
+	spans := exporter.Spans()
+	if len(spans) != 2 || spans[0].Name != "child" || spans[1].Name != "root" {
+		t.Fatalf("exported spans not match.spans:%+v", spans)
+	}
+	c, r := spans[0], spans[1]
+	if c.SpanContext.TraceId != r.SpanContext.TraceId || c.Parent.SpanId != r.SpanContext.SpanId ||
+		r.Parent.IsValid() || !c.SpanContext.IsValid() {
+		t.Errorf("span relation not match.child:%+v root:%+v", c, r)
+	}
+	if c.Attr("k") != "v" || c.Attr("n") != 1 || c.Err == nil || c.EndTime.Before(c.StartTime) {
+		t.Errorf("child span data not match.span:%+v", c)
+	}
+
+	exporter.Reset()
+	if len(exporter.Spans()) != 0 {
+		t.Errorf("reset exporter failed")
+	}
+}
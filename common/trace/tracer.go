@@ -0,0 +1,146 @@
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// SpanData 已结束的span，交给Exporter导出
+type SpanData struct {
+	Name        string
+	SpanContext SpanContext
+	Parent      SpanContext
+	Attributes  []Attribute
+	Err         error
+	StartTime   time.Time
+	EndTime     time.Time
+}
+
+// Attr 返回指定属性的值，不存在时返回nil
+func (t *SpanData) Attr(key string) interface{} {
+	for i := len(t.Attributes) - 1; i >= 0; i-- {
+		if t.Attributes[i].Key == key {
+			return t.Attributes[i].Value
+		}
+	}
+	return nil
+}
+
+// Exporter span结束后调用Export导出，实现需要并发安全
+type Exporter interface {
+	Export(span *SpanData)
+}
+
+type tracer struct {
+	exporter Exporter
+}
+
+// NewTracer 创建简单的Tracer，span结束后交给exporter导出，所有span都采样
+func NewTracer(exporter Exporter) Tracer {
+	return &tracer{exporter: exporter}
+}
+
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &span{
+		tracer: t,
+		data: SpanData{
+			Name:      name,
+			StartTime: time.Now(),
+		},
+	}
+	sc := SpanContext{Sampled: true}
+	if parent := SpanFromContext(ctx); parent != nil && parent.SpanContext().IsValid() {
+		s.data.Parent = parent.SpanContext()
+		sc.TraceId = s.data.Parent.TraceId
+		sc.TraceState = s.data.Parent.TraceState
+	} else {
+		rand.Read(sc.TraceId[:])
+	}
+	rand.Read(sc.SpanId[:])
+	s.data.SpanContext = sc
+
+	return ContextWithSpan(ctx, s), s
+}
+
+type span struct {
+	tracer *tracer
+
+	lock  sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+func (t *span) SpanContext() SpanContext {
+	return t.data.SpanContext
+}
+
+func (t *span) SetAttributes(attrs ...Attribute) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.ended {
+		t.data.Attributes = append(t.data.Attributes, attrs...)
+	}
+}
+
+func (t *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.ended {
+		t.data.Err = err
+	}
+}
+
+// End 结束span，重复调用只导出一次
+func (t *span) End() {
+	t.lock.Lock()
+	if t.ended {
+		t.lock.Unlock()
+		return
+	}
+	t.ended = true
+	t.data.EndTime = time.Now()
+	data := t.data
+	data.Attributes = append([]Attribute{}, t.data.Attributes...)
+	t.lock.Unlock()
+
+	if t.tracer.exporter != nil {
+		t.tracer.exporter.Export(&data)
+	}
+}
+
+// InMemoryExporter 将span保存在内存中，用于测试
+type InMemoryExporter struct {
+	lock  sync.Mutex
+	spans []*SpanData
+}
+
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+func (t *InMemoryExporter) Export(span *SpanData) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.spans = append(t.spans, span)
+}
+
+// Spans 返回已导出的span，按结束顺序排列
+func (t *InMemoryExporter) Spans() []*SpanData {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]*SpanData{}, t.spans...)
+}
+
+func (t *InMemoryExporter) Reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.spans = nil
+}
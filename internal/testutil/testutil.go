@@ -0,0 +1,200 @@
+// Package testutil 单元测试共用的日志、参数及脱敏检查工具，仅供本模块测试使用
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/xuperchain/xasset-sdk-go/auth"
+)
+
+// 日志脱敏测试使用的敏感信息
+const (
+	RedactSK           = "sk0secret0for0redact0test0000000"
+	RedactStsSK        = "sts-sk-secret-for-redact-test"
+	RedactSessionToken = "session-token-secret-for-redact-test"
+	RedactMnemonic     = "mnemonic secret for redact test"
+)
+
+// RedactBody 携带敏感信息的响应体
+func RedactBody(errno int) string {
+	return fmt.Sprintf(`{"request_id":"1","errno":%d,"accessInfo":{"access_key_id":"ak",`+
+		`"secret_access_key":"%s","session_token":"%s"},"mnemonic":"%s","addr":"addr"}`,
+		errno, RedactStsSK, RedactSessionToken, RedactMnemonic)
+}
+
+// CaptureLogger mock logger，记录格式化后的日志用于检查日志内容
+type CaptureLogger struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (t *CaptureLogger) add(lvl, msg string, ctx ...interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.lines = append(t.lines, fmt.Sprintf("[lvl:%s] "+msg, append([]interface{}{lvl}, ctx...)...))
+}
+
+func (t *CaptureLogger) Error(msg string, ctx ...interface{}) {
+	t.add("Error", msg, ctx...)
+}
+
+func (t *CaptureLogger) Warn(msg string, ctx ...interface{}) {
+	t.add("Warn", msg, ctx...)
+}
+
+func (t *CaptureLogger) Info(msg string, ctx ...interface{}) {
+	t.add("Info", msg, ctx...)
+}
+
+func (t *CaptureLogger) Trace(msg string, ctx ...interface{}) {
+	t.add("Trace", msg, ctx...)
+}
+
+func (t *CaptureLogger) Debug(msg string, ctx ...interface{}) {
+	t.add("Debug", msg, ctx...)
+}
+
+func (t *CaptureLogger) Lines() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]string{}, t.lines...)
+}
+
+// AssertNoSecret 检查日志中没有出现任何敏感信息
+func AssertNoSecret(t *testing.T, lines []string, secrets ...string) {
+	t.Helper()
+	for _, line := range lines {
+		for _, secret := range secrets {
+			if secret != "" && strings.Contains(line, secret) {
+				t.Errorf("secret leaked in log.[secret:%s] [log:%s]", secret, line)
+			}
+		}
+	}
+}
+
+// FillParam 填充接口参数，账户使用acc，助记词使用RedactMnemonic
+func FillParam(v reflect.Value, name string, acc *auth.Account) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if reflect.TypeOf(acc).Implements(v.Type()) {
+			v.Set(reflect.ValueOf(acc))
+		}
+	case reflect.Ptr:
+		if v.Type() == reflect.TypeOf(acc) {
+			v.Set(reflect.ValueOf(acc))
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		FillParam(v.Elem(), name, acc)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				FillParam(v.Field(i), v.Type().Field(i).Name, acc)
+			}
+		}
+	case reflect.String:
+		switch {
+		case strings.Contains(name, "Mnemonic"):
+			v.SetString(RedactMnemonic)
+		case strings.Contains(name, "Addr") || name == "From" || name == "To":
+			v.SetString(acc.Address)
+		case name == "OpTyps":
+			v.SetString("[1]")
+		default:
+			v.SetString("1")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Slice:
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if elem.Kind() == reflect.String {
+			elem.SetString("bos_v1://bucket/object/1")
+		} else {
+			FillParam(elem, name, acc)
+		}
+		v.Set(reflect.Append(reflect.MakeSlice(v.Type(), 0, 1), elem))
+	}
+}
+
+// NewClientFunc 使用endpoint、RedactSK及logger创建客户端，同时返回客户端内嵌的基础客户端
+type NewClientFunc func(endpoint string, logger *CaptureLogger) (client, embedded interface{}, err error)
+
+// CheckClientRedact 调用客户端所有带Ctx后缀的接口方法（基础客户端的方法及skip除外），
+// 检查每个方法都发送了请求、成功时只输出一条日志，并且日志中没有sk、签名、私钥、助记词等敏感信息
+func CheckClientRedact(t *testing.T, newClient NewClientFunc, skip ...string) {
+	t.Helper()
+	acc, err := auth.NewXchainEcdsaAccount(auth.MnemStrgthStrong, auth.MnemLangCN)
+	if err != nil {
+		t.Fatalf("create account failed.err:%v", err)
+	}
+
+	var reqCnt int32
+	var lock sync.Mutex
+	var authStrs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&reqCnt, 1)
+		lock.Lock()
+		authStrs = append(authStrs, r.Header.Get("Authorization"))
+		lock.Unlock()
+		w.Write([]byte(RedactBody(0)))
+	}))
+	defer srv.Close()
+
+	logger := &CaptureLogger{}
+	handle, embedded, err := newClient(srv.URL, logger)
+	if err != nil {
+		t.Fatalf("new client failed.err:%v", err)
+	}
+	skipSet := make(map[string]bool)
+	for _, name := range skip {
+		skipSet[name] = true
+	}
+
+	typ := reflect.TypeOf(handle)
+	baseTyp := reflect.TypeOf(embedded)
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if _, ok := baseTyp.MethodByName(m.Name); ok || !strings.HasSuffix(m.Name, "Ctx") || skipSet[m.Name] {
+			continue
+		}
+		args := []reflect.Value{reflect.ValueOf(handle), reflect.ValueOf(context.Background())}
+		for j := 2; j < m.Type.NumIn(); j++ {
+			arg := reflect.New(m.Type.In(j)).Elem()
+			FillParam(arg, "", acc)
+			args = append(args, arg)
+		}
+		before, logCnt := atomic.LoadInt32(&reqCnt), len(logger.Lines())
+		m.Func.Call(args)
+		if atomic.LoadInt32(&reqCnt) == before {
+			t.Errorf("%s did not send request, params need to be fixed", m.Name)
+		}
+		// 成功的调用只由Call输出一条结构化日志
+		var traces []string
+		for _, line := range logger.Lines()[logCnt:] {
+			if strings.HasPrefix(line, "[lvl:Trace]") {
+				traces = append(traces, line)
+			}
+		}
+		if len(traces) != 1 {
+			t.Errorf("%s should log once per succ call.lines:%v", m.Name, traces)
+		}
+	}
+
+	secrets := []string{RedactSK, RedactStsSK, RedactSessionToken, RedactMnemonic, acc.PrivateKey, acc.Mnemonic}
+	lock.Lock()
+	for _, a := range authStrs {
+		secrets = append(secrets, a[strings.LastIndex(a, "/")+1:])
+	}
+	lock.Unlock()
+	AssertNoSecret(t, logger.Lines(), secrets...)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/xuperchain/xasset-sdk-go/common/config"
+	"github.com/xuperchain/xasset-sdk-go/tools/xasset-cli/common"
+
+	"github.com/spf13/cobra"
+)
+
+type ConfigCmd struct {
+	BaseCmd
+}
+
+func GetConfigCmd() *ConfigCmd {
+	cmdIns := new(ConfigCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "config",
+		Short:         "Xasset client config operation.",
+		Example:       common.CmdLineName + " config check [arguments]",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmdIns.Cmd.AddCommand(GetCheckConfigCmd().GetCmd())
+
+	return cmdIns
+}
+
+// check config command
+type CheckConfigCmd struct {
+	BaseCmd
+	// 要绑定的变量类型只能使用内置基础类型
+	File    string
+	Profile string
+}
+
+func GetCheckConfigCmd() *CheckConfigCmd {
+	cmdIns := new(CheckConfigCmd)
+
+	cmdIns.Cmd = &cobra.Command{
+		Use:           "check",
+		Short:         "Load and validate client config from file and XASSET_* environment variables.",
+		Example:       common.CmdLineName + " config check -c ./xasset.yaml -p prod",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdIns.Check()
+		},
+	}
+
+	// 设置命令行参数并绑定变量
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.File, "conf", "c", "", "config file path. yaml|yml|json")
+	cmdIns.Cmd.Flags().StringVarP(&cmdIns.Profile, "profile", "p", "", "config profile. dev|staging|prod")
+
+	return cmdIns
+}
+
+// print loaded config
+func (t *CheckConfigCmd) Check() error {
+	cfg, err := LoadConfig(t.File, t.Profile)
+	if err != nil {
+		fmt.Printf("%s\n%v\n", common.FailedRespMsg, err)
+		return nil
+	}
+
+	fmt.Printf("endpoint: %s\napp_id: %d\naccess_key_id: %s\n",
+		cfg.Endpoint, cfg.Credentials.AppId, cfg.Credentials.AccessKeyId)
+	return nil
+}
+
+// LoadConfig 加载客户端配置，file和profile为空时读取XASSET_CONFIG_FILE和XASSET_PROFILE
+func LoadConfig(file, profile string) (*config.XassetCliConfig, error) {
+	return config.LoadConfig(&config.LoadOptions{
+		File:    file,
+		Profile: profile,
+	})
+}